@@ -7,15 +7,52 @@ package main
 import "C"
 import (
 	"context"
+	"errors"
+	"strings"
 	"time"
 	"unsafe"
 
+	"storj.io/common/encryption"
+	"storj.io/common/grant"
+	"storj.io/common/macaroon"
+	"storj.io/common/paths"
+	"storj.io/common/storj"
 	"storj.io/uplink"
+	privateAccess "storj.io/uplink/private/access"
 )
 
 // Access grant contains everything to access a project and specific buckets.
 type Access struct {
 	*uplink.Access
+
+	// pathEncryptionBypass is not part of the serialized access grant, so it
+	// needs to be enabled again for accesses derived from this one.
+	pathEncryptionBypass bool
+
+	// overrides are the encryption key overrides added to this access or to
+	// the accesses it was derived from. The serialized access grant cannot
+	// tell an override of a shared prefix from the key of the shared prefix.
+	overrides map[inspectedPrefix]bool
+}
+
+// derive returns access with the settings of acc that are not part of the
+// serialized access grant.
+func (acc *Access) derive(access *uplink.Access) (*Access, error) {
+	if acc.pathEncryptionBypass {
+		if err := privateAccess.EnablePathEncryptionBypass(access); err != nil {
+			return nil, err
+		}
+	}
+	overrides := make(map[inspectedPrefix]bool, len(acc.overrides))
+	for override := range acc.overrides {
+		overrides[override] = true
+	}
+
+	return &Access{
+		Access:               access,
+		pathEncryptionBypass: acc.pathEncryptionBypass,
+		overrides:            overrides,
+	}, nil
 }
 
 // uplink_parse_access parses serialized access grant string.
@@ -30,7 +67,7 @@ func uplink_parse_access(accessString *C.uplink_const_char) C.UplinkAccessResult
 	}
 
 	return C.UplinkAccessResult{
-		access: (*C.UplinkAccess)(mallocHandle(universe.Add(&Access{access}))),
+		access: (*C.UplinkAccess)(mallocHandle(universe.Add(&Access{Access: access}))),
 	}
 }
 
@@ -55,7 +92,53 @@ func uplink_request_access_with_passphrase(satellite_address, api_key, passphras
 	}
 
 	ctx := context.Background()
+	span := startSpan(&ctx, nil, "uplink_request_access_with_passphrase", "", "")
 	access, err := uplink.RequestAccessWithPassphrase(ctx, C.GoString(satellite_address), C.GoString(api_key), C.GoString(passphrase))
+	span.finish(err)
+	if err != nil {
+		return C.UplinkAccessResult{
+			error: mallocError(err),
+		}
+	}
+
+	return C.UplinkAccessResult{
+		access: (*C.UplinkAccess)(mallocHandle(universe.Add(&Access{Access: access}))),
+	}
+}
+
+// uplink_new_access creates a new access grant from a serialized API key and an
+// encryption key, without contacting the satellite.
+//
+// The encryption key is used as the root key of the access grant. For reading
+// objects uploaded with uplink_request_access_with_passphrase, it must be
+// derived with uplink_derive_passphrase_key from the project salt.
+//
+//export uplink_new_access
+func uplink_new_access(satellite_address, api_key *C.uplink_const_char, encryptionKey *C.UplinkEncryptionKey) C.UplinkAccessResult { //nolint:golint
+	if satellite_address == nil {
+		return C.UplinkAccessResult{
+			error: mallocError(ErrNull.New("satellite_address")),
+		}
+	}
+	if api_key == nil {
+		return C.UplinkAccessResult{
+			error: mallocError(ErrNull.New("api_key")),
+		}
+	}
+	if encryptionKey == nil {
+		return C.UplinkAccessResult{
+			error: mallocError(ErrNull.New("encryption key")),
+		}
+	}
+
+	encKey, ok := universe.Get(encryptionKey._handle).(*EncryptionKey)
+	if !ok {
+		return C.UplinkAccessResult{
+			error: mallocError(ErrInvalidHandle.New("encryption key")),
+		}
+	}
+
+	access, err := newAccess(C.GoString(satellite_address), C.GoString(api_key), encKey.key)
 	if err != nil {
 		return C.UplinkAccessResult{
 			error: mallocError(err),
@@ -63,10 +146,37 @@ func uplink_request_access_with_passphrase(satellite_address, api_key, passphras
 	}
 
 	return C.UplinkAccessResult{
-		access: (*C.UplinkAccess)(mallocHandle(universe.Add(&Access{access}))),
+		access: (*C.UplinkAccess)(mallocHandle(universe.Add(&Access{Access: access}))),
 	}
 }
 
+// newAccess creates an access grant the same way as
+// uplink.RequestAccessWithPassphrase, using key as the root key.
+func newAccess(satelliteAddress, apiKey string, key *storj.Key) (*uplink.Access, error) {
+	if satelliteAddress == "" {
+		return nil, ErrInvalidArg.New("satellite_address is empty")
+	}
+
+	parsedAPIKey, err := macaroon.ParseAPIKey(apiKey)
+	if err != nil {
+		return nil, ErrInvalidArg.Wrap(err)
+	}
+
+	encAccess := grant.NewEncryptionAccessWithDefaultKey(key)
+	encAccess.SetDefaultPathCipher(storj.EncAESGCM)
+	encAccess.LimitTo(parsedAPIKey)
+
+	access, err := fromGrant(&grant.Access{
+		SatelliteAddress: satelliteAddress,
+		APIKey:           parsedAPIKey,
+		EncAccess:        encAccess,
+	})
+	if err != nil {
+		return nil, ErrInvalidArg.Wrap(err)
+	}
+	return access, nil
+}
+
 // uplink_access_satellite_address returns the satellite node URL for this access grant.
 //
 //export uplink_access_satellite_address
@@ -85,7 +195,7 @@ func uplink_access_satellite_address(access *C.UplinkAccess) C.UplinkStringResul
 	}
 
 	return C.UplinkStringResult{
-		string: C.CString(acc.Access.SatelliteAddress()),
+		string: cstring(acc.Access.SatelliteAddress()),
 	}
 }
 
@@ -113,7 +223,7 @@ func uplink_access_serialize(access *C.UplinkAccess) C.UplinkStringResult {
 		}
 	}
 	return C.UplinkStringResult{
-		string: C.CString(str),
+		string: cstring(str),
 	}
 }
 
@@ -134,43 +244,211 @@ func uplink_access_share(access *C.UplinkAccess, permission C.UplinkPermission,
 		}
 	}
 
-	perm := uplink.Permission{
-		AllowDownload: bool(permission.allow_download),
-		AllowUpload:   bool(permission.allow_upload),
-		AllowList:     bool(permission.allow_list),
-		AllowDelete:   bool(permission.allow_delete),
-	}
-
-	if permission.not_before != 0 {
-		perm.NotBefore = time.Unix(int64(permission.not_before), 0)
-	}
-	if permission.not_after != 0 {
-		perm.NotAfter = time.Unix(int64(permission.not_after), 0)
+	perm, err := sharePermission(permission)
+	if err != nil {
+		return C.UplinkAccessResult{
+			error: mallocError(err),
+		}
 	}
 
-	var goprefixes []uplink.SharePrefix
+	var goprefixes []grant.SharePrefix
 	if prefixes != nil && prefixes_count > 0 {
 		array := unsafe.Slice(prefixes, prefixes_count)
 
 		for _, p := range array {
-			goprefixes = append(goprefixes, uplink.SharePrefix{
+			goprefixes = append(goprefixes, grant.SharePrefix{
 				Bucket: C.GoString(p.bucket),
 				Prefix: C.GoString(p.prefix),
 			})
 		}
 	}
 
-	newAccess, err := acc.Share(perm, goprefixes...)
+	shared, err := shareAccess(acc.Access, perm, goprefixes)
+	if err != nil {
+		return C.UplinkAccessResult{
+			error: mallocError(err),
+		}
+	}
+	newAccess, err := acc.derive(shared)
 	if err != nil {
 		return C.UplinkAccessResult{
 			error: mallocError(err),
 		}
 	}
 	return C.UplinkAccessResult{
-		access: (*C.UplinkAccess)(mallocHandle(universe.Add(&Access{newAccess}))),
+		access: (*C.UplinkAccess)(mallocHandle(universe.Add(newAccess))),
 	}
 }
 
+// uplink_access_share_prefixes creates access grants with a permission for
+// each prefix.
+//
+// All caveats of an API key must allow a request, so a single access grant
+// cannot give different permissions to different prefixes. An access grant
+// is created for each distinct permission of the entries, restricted to the
+// prefixes of the entries with that permission. The access grants are in the
+// order in which their permissions first appear in entries.
+//
+//export uplink_access_share_prefixes
+func uplink_access_share_prefixes(access *C.UplinkAccess, entries *C.UplinkSharePrefixPermission, entries_count int) C.UplinkSharePrefixesResult { //nolint:golint
+	if access == nil {
+		return C.UplinkSharePrefixesResult{
+			error: mallocError(ErrNull.New("access")),
+		}
+	}
+
+	acc, ok := universe.Get(access._handle).(*Access)
+	if !ok {
+		return C.UplinkSharePrefixesResult{
+			error: mallocError(ErrInvalidHandle.New("access")),
+		}
+	}
+
+	if entries == nil {
+		return C.UplinkSharePrefixesResult{
+			error: mallocError(ErrNull.New("entries")),
+		}
+	}
+	if entries_count <= 0 {
+		return C.UplinkSharePrefixesResult{
+			error: mallocError(ErrInvalidArg.New("entries_count: at least one entry is required")),
+		}
+	}
+
+	type prefixGroup struct {
+		permission C.UplinkPermission
+		prefixes   []grant.SharePrefix
+	}
+
+	var groups []*prefixGroup
+	for _, entry := range unsafe.Slice(entries, entries_count) {
+		var group *prefixGroup
+		for _, g := range groups {
+			if g.permission == entry.permission {
+				group = g
+				break
+			}
+		}
+		if group == nil {
+			group = &prefixGroup{permission: entry.permission}
+			groups = append(groups, group)
+		}
+
+		group.prefixes = append(group.prefixes, grant.SharePrefix{
+			Bucket: C.GoString(entry.prefix.bucket),
+			Prefix: C.GoString(entry.prefix.prefix),
+		})
+	}
+
+	shared := make([]*Access, 0, len(groups))
+	for _, group := range groups {
+		perm, err := sharePermission(group.permission)
+		if err != nil {
+			return C.UplinkSharePrefixesResult{
+				error: mallocError(err),
+			}
+		}
+
+		restricted, err := shareAccess(acc.Access, perm, group.prefixes)
+		if err != nil {
+			return C.UplinkSharePrefixesResult{
+				error: mallocError(err),
+			}
+		}
+		newAccess, err := acc.derive(restricted)
+		if err != nil {
+			return C.UplinkSharePrefixesResult{
+				error: mallocError(err),
+			}
+		}
+		shared = append(shared, newAccess)
+	}
+
+	accesses := (**C.UplinkAccess)(calloc(C.size_t(len(shared)), C.size_t(unsafe.Sizeof((*C.UplinkAccess)(nil)))))
+	array := unsafe.Slice(accesses, len(shared))
+	for i, newAccess := range shared {
+		array[i] = (*C.UplinkAccess)(mallocHandle(universe.Add(newAccess)))
+	}
+
+	return C.UplinkSharePrefixesResult{
+		accesses:       accesses,
+		accesses_count: C.size_t(len(shared)),
+	}
+}
+
+// uplink_free_share_prefixes_result frees the resources associated with share prefixes result.
+//
+//export uplink_free_share_prefixes_result
+func uplink_free_share_prefixes_result(result C.UplinkSharePrefixesResult) {
+	uplink_free_error(result.error)
+	if result.accesses == nil {
+		return
+	}
+	defer free(unsafe.Pointer(result.accesses))
+
+	for _, access := range unsafe.Slice(result.accesses, int(result.accesses_count)) {
+		freeAccess(access)
+	}
+}
+
+// sharePermission converts permission and checks the fields that cannot be
+// used together.
+func sharePermission(permission C.UplinkPermission) (grant.Permission, error) {
+	perm := grant.Permission{
+		AllowDownload:                  bool(permission.allow_download),
+		AllowUpload:                    bool(permission.allow_upload),
+		AllowList:                      bool(permission.allow_list),
+		AllowDelete:                    bool(permission.allow_delete),
+		AllowPutObjectRetention:        bool(permission.allow_put_object_retention),
+		AllowGetObjectRetention:        bool(permission.allow_get_object_retention),
+		AllowPutObjectLegalHold:        bool(permission.allow_put_object_legal_hold),
+		AllowGetObjectLegalHold:        bool(permission.allow_get_object_legal_hold),
+		AllowBypassGovernanceRetention: bool(permission.allow_bypass_governance_retention),
+	}
+
+	if permission.not_before != 0 {
+		perm.NotBefore = time.Unix(int64(permission.not_before), 0)
+	}
+	if permission.not_after != 0 {
+		perm.NotAfter = time.Unix(int64(permission.not_after), 0)
+	}
+	if !perm.NotBefore.IsZero() && !perm.NotAfter.IsZero() && perm.NotAfter.Before(perm.NotBefore) {
+		return grant.Permission{}, ErrInvalidArg.New("not_after: must not be before not_before")
+	}
+
+	switch {
+	case permission.max_object_ttl < 0:
+		return grant.Permission{}, ErrInvalidArg.New("max_object_ttl: must not be negative")
+	case permission.max_object_ttl > 0:
+		if !perm.AllowUpload {
+			return grant.Permission{}, ErrInvalidArg.New("max_object_ttl: requires allow_upload")
+		}
+		ttl := time.Duration(permission.max_object_ttl) * time.Second
+		perm.MaxObjectTTL = &ttl
+	}
+
+	if perm.AllowBypassGovernanceRetention && !perm.AllowDelete && !perm.AllowPutObjectRetention {
+		return grant.Permission{}, ErrInvalidArg.New("allow_bypass_governance_retention: requires allow_delete or allow_put_object_retention")
+	}
+
+	return perm, nil
+}
+
+// shareAccess restricts access to perm and prefixes. Unlike
+// uplink.Access.Share it supports all permissions of the API key.
+func shareAccess(access *uplink.Access, perm grant.Permission, prefixes []grant.SharePrefix) (*uplink.Access, error) {
+	g, err := toGrant(access)
+	if err != nil {
+		return nil, err
+	}
+
+	restricted, err := g.Restrict(perm, prefixes...)
+	if err != nil {
+		return nil, err
+	}
+	return fromGrant(restricted)
+}
+
 // uplink_access_override_encryption_key overrides the root encryption key for the prefix in
 // bucket with encryptionKey.
 //
@@ -197,16 +475,55 @@ func uplink_access_override_encryption_key(access *C.UplinkAccess, bucket, prefi
 		return mallocError(ErrInvalidHandle.New("encryption key"))
 	}
 
-	err := acc.OverrideEncryptionKey(C.GoString(bucket), C.GoString(prefix), encKey.EncryptionKey)
+	err := acc.overrideEncryptionKey(C.GoString(bucket), C.GoString(prefix), encKey.key)
 	return mallocError(err)
 }
 
+// overrideEncryptionKey does the same as uplink.Access.OverrideEncryptionKey,
+// which cannot be used with a raw key.
+func (acc *Access) overrideEncryptionKey(bucket, prefix string, key *storj.Key) error {
+	if !strings.HasSuffix(prefix, "/") {
+		return errors.New("prefix must end with slash")
+	}
+
+	// The trailing slash is removed, otherwise the encrypted prefix would
+	// end with an encrypted empty segment.
+	prefix = strings.TrimSuffix(prefix, "/")
+
+	g, err := toGrant(acc.Access)
+	if err != nil {
+		return err
+	}
+
+	store := g.EncAccess.Store
+	unencPath := paths.NewUnencrypted(prefix)
+	encPath, err := encryption.EncryptPathWithStoreCipher(bucket, unencPath, store)
+	if err != nil {
+		return err
+	}
+	if err := store.Add(bucket, unencPath, encPath, *key); err != nil {
+		return err
+	}
+
+	access, err := fromGrant(g)
+	if err != nil {
+		return err
+	}
+	derived, err := acc.derive(access)
+	if err != nil {
+		return err
+	}
+	derived.overrides[overridePrefix(bucket, prefix)] = true
+	*acc = *derived
+	return nil
+}
+
 // uplink_free_string_result frees the resources associated with string result.
 //
 //export uplink_free_string_result
 func uplink_free_string_result(result C.UplinkStringResult) {
 	uplink_free_error(result.error)
-	C.free(unsafe.Pointer(result.string))
+	free(unsafe.Pointer(result.string))
 }
 
 // uplink_free_access_result frees the resources associated with access grant.
@@ -221,6 +538,6 @@ func freeAccess(access *C.UplinkAccess) {
 	if access == nil {
 		return
 	}
-	defer C.free(unsafe.Pointer(access))
+	defer free(unsafe.Pointer(access))
 	defer universe.Del(access._handle)
 }
@@ -0,0 +1,314 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"storj.io/common/encryption"
+	"storj.io/common/grant"
+	"storj.io/common/macaroon"
+	"storj.io/common/paths"
+	"storj.io/common/storj"
+)
+
+// accessInspection is the JSON description of an access grant.
+type accessInspection struct {
+	SatelliteAddress string              `json:"satellite_address"`
+	Permission       inspectedPermission `json:"permission"`
+	Prefixes         []inspectedPrefix   `json:"prefixes"`
+	Caveats          []macaroon.Caveat   `json:"caveats"`
+	HasOverrides     bool                `json:"has_encryption_key_overrides"`
+	Overrides        []inspectedPrefix   `json:"-"`
+
+	UnencryptedObjectKeys bool `json:"unencrypted_object_keys"`
+	PathEncryptionBypass  bool `json:"path_encryption_bypass"`
+}
+
+// inspectedPermission is the effective permission of all API key caveats.
+type inspectedPermission struct {
+	AllowDownload                  bool   `json:"allow_download"`
+	AllowUpload                    bool   `json:"allow_upload"`
+	AllowList                      bool   `json:"allow_list"`
+	AllowDelete                    bool   `json:"allow_delete"`
+	AllowPutObjectRetention        bool   `json:"allow_put_object_retention"`
+	AllowGetObjectRetention        bool   `json:"allow_get_object_retention"`
+	AllowPutObjectLegalHold        bool   `json:"allow_put_object_legal_hold"`
+	AllowGetObjectLegalHold        bool   `json:"allow_get_object_legal_hold"`
+	AllowBypassGovernanceRetention bool   `json:"allow_bypass_governance_retention"`
+	NotBefore                      *int64 `json:"not_before"`
+	NotAfter                       *int64 `json:"not_after"`
+	MaxObjectTTLSeconds            *int64 `json:"max_object_ttl_seconds"`
+}
+
+// inspectedPrefix is a bucket and an unencrypted prefix.
+type inspectedPrefix struct {
+	Bucket string `json:"bucket"`
+	Prefix string `json:"prefix"`
+}
+
+// uplink_access_inspect returns a JSON description of the access grant.
+//
+// The description contains the satellite address, the effective permission
+// of the API key, the bucket and prefix restrictions, the API key caveats,
+// whether the access has encryption key overrides and the object key
+// encryption settings. "prefixes" are the prefixes allowed by all caveats,
+// they are null when the access is not restricted to prefixes. Times are in
+// Unix seconds and null when not limited.
+//
+//export uplink_access_inspect
+func uplink_access_inspect(access *C.UplinkAccess) C.UplinkStringResult {
+	if access == nil {
+		return C.UplinkStringResult{
+			error: mallocError(ErrNull.New("access")),
+		}
+	}
+
+	acc, ok := universe.Get(access._handle).(*Access)
+	if !ok {
+		return C.UplinkStringResult{
+			error: mallocError(ErrInvalidHandle.New("access")),
+		}
+	}
+
+	g, err := toGrant(acc.Access)
+	if err != nil {
+		return C.UplinkStringResult{
+			error: mallocError(err),
+		}
+	}
+
+	inspection, err := inspectGrant(g, acc.overrides)
+	if err != nil {
+		return C.UplinkStringResult{
+			error: mallocError(err),
+		}
+	}
+	inspection.PathEncryptionBypass = acc.pathEncryptionBypass
+
+	data, err := json.Marshal(inspection)
+	if err != nil {
+		return C.UplinkStringResult{
+			error: mallocError(err),
+		}
+	}
+
+	return C.UplinkStringResult{
+		string: cstring(string(data)),
+	}
+}
+
+// uplink_access_expires_at returns when the access grant expires.
+//
+// The expiry is the earliest not_after of all API key caveats, it is 0 when
+// the access does not expire.
+//
+//export uplink_access_expires_at
+func uplink_access_expires_at(access *C.UplinkAccess) C.UplinkTimeResult {
+	if access == nil {
+		return C.UplinkTimeResult{
+			error: mallocError(ErrNull.New("access")),
+		}
+	}
+
+	acc, ok := universe.Get(access._handle).(*Access)
+	if !ok {
+		return C.UplinkTimeResult{
+			error: mallocError(ErrInvalidHandle.New("access")),
+		}
+	}
+
+	g, err := toGrant(acc.Access)
+	if err != nil {
+		return C.UplinkTimeResult{
+			error: mallocError(err),
+		}
+	}
+
+	caveats, err := apiKeyCaveats(g.APIKey)
+	if err != nil {
+		return C.UplinkTimeResult{
+			error: mallocError(err),
+		}
+	}
+
+	_, notAfter, _ := caveatLimits(caveats)
+	if notAfter == nil {
+		return C.UplinkTimeResult{}
+	}
+	return C.UplinkTimeResult{
+		time: C.int64_t(notAfter.Unix()),
+	}
+}
+
+// uplink_free_time_result frees the resources associated with time result.
+//
+//export uplink_free_time_result
+func uplink_free_time_result(result C.UplinkTimeResult) {
+	uplink_free_error(result.error)
+}
+
+// inspectGrant describes the grant, overrides are the encryption key
+// overrides known to be added to it.
+func inspectGrant(g *grant.Access, overrides map[inspectedPrefix]bool) (*accessInspection, error) {
+	caveats, err := apiKeyCaveats(g.APIKey)
+	if err != nil {
+		return nil, err
+	}
+
+	inspection := &accessInspection{
+		SatelliteAddress: g.SatelliteAddress,
+		Permission:       effectivePermission(caveats),
+		Caveats:          caveats,
+	}
+	if inspection.Caveats == nil {
+		inspection.Caveats = []macaroon.Caveat{}
+	}
+
+	allowed := allowedPaths(caveats)
+	for _, path := range allowed {
+		bucket, encPath := string(path.Bucket), paths.NewEncrypted(string(path.EncryptedPathPrefix))
+		unencPath, err := encryption.DecryptPathWithStoreCipher(bucket, encPath, g.EncAccess.Store)
+		if err != nil {
+			// the prefix was not shared with the access
+			continue
+		}
+		inspection.Prefixes = append(inspection.Prefixes, inspectedPrefix{Bucket: bucket, Prefix: unencPath.Raw()})
+	}
+	if allowed != nil && inspection.Prefixes == nil {
+		inspection.Prefixes = []inspectedPrefix{}
+	}
+
+	inspection.Overrides, err = grantOverrides(g, overrides)
+	if err != nil {
+		return nil, err
+	}
+	inspection.HasOverrides = len(inspection.Overrides) > 0
+	inspection.UnencryptedObjectKeys = g.EncAccess.Store.GetDefaultPathCipher() == storj.EncNull
+
+	return inspection, nil
+}
+
+// allowedPaths returns the paths allowed by all caveats, which is nil when
+// no caveat restricts the paths.
+//
+// A caveat allows the paths under any of its allowed paths, so the result is
+// the intersection of these unions. Two paths intersect in the longer one,
+// when it's under the shorter one.
+func allowedPaths(caveats []macaroon.Caveat) []*macaroon.Caveat_Path {
+	var allowed []*macaroon.Caveat_Path
+	restricted := false
+	for _, caveat := range caveats {
+		if len(caveat.AllowedPaths) == 0 {
+			continue
+		}
+		if !restricted {
+			restricted = true
+			allowed = caveat.AllowedPaths
+			continue
+		}
+
+		var intersection []*macaroon.Caveat_Path
+		for _, a := range allowed {
+			for _, b := range caveat.AllowedPaths {
+				if path, ok := intersectPaths(a, b); ok && !containsPath(intersection, path) {
+					intersection = append(intersection, path)
+				}
+			}
+		}
+		allowed = intersection
+	}
+	if restricted && allowed == nil {
+		allowed = []*macaroon.Caveat_Path{}
+	}
+	return allowed
+}
+
+// intersectPaths returns the path allowed by both a and b.
+func intersectPaths(a, b *macaroon.Caveat_Path) (*macaroon.Caveat_Path, bool) {
+	if !bytes.Equal(a.Bucket, b.Bucket) {
+		return nil, false
+	}
+	switch {
+	case bytes.HasPrefix(b.EncryptedPathPrefix, a.EncryptedPathPrefix):
+		return b, true
+	case bytes.HasPrefix(a.EncryptedPathPrefix, b.EncryptedPathPrefix):
+		return a, true
+	}
+	return nil, false
+}
+
+// containsPath returns whether path is in list.
+func containsPath(list []*macaroon.Caveat_Path, path *macaroon.Caveat_Path) bool {
+	for _, p := range list {
+		if bytes.Equal(p.Bucket, path.Bucket) && bytes.Equal(p.EncryptedPathPrefix, path.EncryptedPathPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// effectivePermission combines the restrictions of all caveats.
+func effectivePermission(caveats []macaroon.Caveat) inspectedPermission {
+	permission := inspectedPermission{
+		AllowDownload:                  true,
+		AllowUpload:                    true,
+		AllowList:                      true,
+		AllowDelete:                    true,
+		AllowPutObjectRetention:        true,
+		AllowGetObjectRetention:        true,
+		AllowPutObjectLegalHold:        true,
+		AllowGetObjectLegalHold:        true,
+		AllowBypassGovernanceRetention: true,
+	}
+
+	for _, caveat := range caveats {
+		permission.AllowDownload = permission.AllowDownload && !caveat.DisallowReads
+		permission.AllowUpload = permission.AllowUpload && !caveat.DisallowWrites
+		permission.AllowList = permission.AllowList && !caveat.DisallowLists
+		permission.AllowDelete = permission.AllowDelete && !caveat.DisallowDeletes
+		permission.AllowPutObjectRetention = permission.AllowPutObjectRetention && !caveat.DisallowPutRetention
+		// put retention permission implies get retention permission.
+		permission.AllowGetObjectRetention = permission.AllowGetObjectRetention && !(caveat.DisallowPutRetention && caveat.DisallowGetRetention)
+		permission.AllowPutObjectLegalHold = permission.AllowPutObjectLegalHold && !caveat.DisallowPutLegalHold
+		permission.AllowGetObjectLegalHold = permission.AllowGetObjectLegalHold && !caveat.DisallowGetLegalHold
+		permission.AllowBypassGovernanceRetention = permission.AllowBypassGovernanceRetention && !caveat.DisallowBypassGovernanceRetention
+	}
+
+	notBefore, notAfter, maxObjectTTL := caveatLimits(caveats)
+	if notBefore != nil {
+		unix := notBefore.Unix()
+		permission.NotBefore = &unix
+	}
+	if notAfter != nil {
+		unix := notAfter.Unix()
+		permission.NotAfter = &unix
+	}
+	if maxObjectTTL != nil {
+		seconds := int64(maxObjectTTL.Seconds())
+		permission.MaxObjectTTLSeconds = &seconds
+	}
+	return permission
+}
+
+// caveatLimits returns the latest not before, the earliest not after and the
+// shortest max object TTL of the caveats. They are nil when not limited.
+func caveatLimits(caveats []macaroon.Caveat) (notBefore, notAfter *time.Time, maxObjectTTL *time.Duration) {
+	for _, caveat := range caveats {
+		if caveat.NotBefore != nil && (notBefore == nil || caveat.NotBefore.After(*notBefore)) {
+			notBefore = caveat.NotBefore
+		}
+		if caveat.NotAfter != nil && (notAfter == nil || caveat.NotAfter.Before(*notAfter)) {
+			notAfter = caveat.NotAfter
+		}
+		if caveat.MaxObjectTtl != nil && (maxObjectTTL == nil || *caveat.MaxObjectTtl < *maxObjectTTL) {
+			maxObjectTTL = caveat.MaxObjectTtl
+		}
+	}
+	return notBefore, notAfter, maxObjectTTL
+}
@@ -0,0 +1,121 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/grant"
+	"storj.io/common/macaroon"
+	"storj.io/common/testrand"
+	"storj.io/uplink"
+)
+
+// newTestAccess creates an unrestricted access without contacting a satellite.
+func newTestAccess(t *testing.T) *uplink.Access {
+	apiKey, err := macaroon.NewAPIKey(testrand.BytesInt(32))
+	require.NoError(t, err)
+
+	key := testrand.Key()
+	access, err := fromGrant(&grant.Access{
+		SatelliteAddress: "1111111111111111111111111111111VyS547o@127.0.0.1:7777",
+		APIKey:           apiKey,
+		EncAccess:        grant.NewEncryptionAccessWithDefaultKey(&key),
+	})
+	require.NoError(t, err)
+	return access
+}
+
+func TestAccessInspectNestedShares(t *testing.T) {
+	access := newTestAccess(t)
+
+	outer, err := access.Share(uplink.FullPermission(),
+		uplink.SharePrefix{Bucket: "alpha", Prefix: "photos/"},
+		uplink.SharePrefix{Bucket: "beta"},
+	)
+	require.NoError(t, err)
+
+	inner, err := outer.Share(uplink.FullPermission(),
+		uplink.SharePrefix{Bucket: "alpha", Prefix: "photos/2020/"},
+		uplink.SharePrefix{Bucket: "beta", Prefix: "docs/"},
+	)
+	require.NoError(t, err)
+
+	g, err := toGrant(inner)
+	require.NoError(t, err)
+	inspection, err := inspectGrant(g, nil)
+	require.NoError(t, err)
+
+	// only the prefixes allowed by both caveats are reported
+	require.Len(t, inspection.Caveats, 2)
+	require.Equal(t, []inspectedPrefix{
+		{Bucket: "alpha", Prefix: "photos/2020"},
+		{Bucket: "beta", Prefix: "docs"},
+	}, inspection.Prefixes)
+
+	// no bucket is allowed by all caveats
+	g.APIKey, err = g.APIKey.Restrict(macaroon.Caveat{
+		AllowedPaths: []*macaroon.Caveat_Path{{Bucket: []byte("gamma")}},
+	})
+	require.NoError(t, err)
+	inspection, err = inspectGrant(g, nil)
+	require.NoError(t, err)
+	require.NotNil(t, inspection.Prefixes)
+	require.Empty(t, inspection.Prefixes)
+}
+
+func TestAccessInspect(t *testing.T) {
+	access := newTestAccess(t)
+
+	g, err := toGrant(access)
+	require.NoError(t, err)
+	inspection, err := inspectGrant(g, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, "1111111111111111111111111111111VyS547o@127.0.0.1:7777", inspection.SatelliteAddress)
+	require.True(t, inspection.Permission.AllowDownload)
+	require.True(t, inspection.Permission.AllowDelete)
+	require.Nil(t, inspection.Permission.NotAfter)
+	require.Nil(t, inspection.Prefixes)
+	require.Empty(t, inspection.Caveats)
+	require.False(t, inspection.HasOverrides)
+
+	notAfter := time.Now().Add(time.Hour).Truncate(time.Second)
+	shared, err := access.Share(uplink.Permission{
+		AllowDownload: true,
+		AllowList:     true,
+		NotAfter:      notAfter,
+	}, uplink.SharePrefix{Bucket: "alpha", Prefix: "photos/"})
+	require.NoError(t, err)
+
+	g, err = toGrant(shared)
+	require.NoError(t, err)
+	inspection, err = inspectGrant(g, nil)
+	require.NoError(t, err)
+
+	require.True(t, inspection.Permission.AllowDownload)
+	require.True(t, inspection.Permission.AllowList)
+	require.False(t, inspection.Permission.AllowUpload)
+	require.False(t, inspection.Permission.AllowDelete)
+	require.Equal(t, notAfter.Unix(), *inspection.Permission.NotAfter)
+	// the grant stores the prefix up to the last slash.
+	require.Equal(t, []inspectedPrefix{{Bucket: "alpha", Prefix: "photos"}}, inspection.Prefixes)
+	require.Len(t, inspection.Caveats, 1)
+	require.False(t, inspection.HasOverrides)
+
+	overrideKey, err := uplink.DeriveEncryptionKey("override", []byte("salt"))
+	require.NoError(t, err)
+	require.NoError(t, access.OverrideEncryptionKey("beta", "tenant/", overrideKey))
+
+	g, err = toGrant(access)
+	require.NoError(t, err)
+	inspection, err = inspectGrant(g, nil)
+	require.NoError(t, err)
+
+	require.True(t, inspection.HasOverrides)
+	require.Equal(t, []inspectedPrefix{{Bucket: "beta", Prefix: "tenant"}}, inspection.Overrides)
+}
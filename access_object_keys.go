@@ -0,0 +1,105 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+import (
+	"storj.io/common/storj"
+	"storj.io/uplink"
+	privateAccess "storj.io/uplink/private/access"
+)
+
+// uplink_access_disable_object_key_encryption creates a new access grant,
+// which doesn't encrypt the object keys of uploaded objects. access is not
+// changed.
+//
+// Object keys are stored in plain text in the satellite database, which
+// allows readable listings. Object content is still encrypted. The setting
+// is part of the serialized access grant and is kept by uplink_access_share.
+// Encryption key overrides keep their own setting.
+//
+// Objects uploaded with encrypted keys are not accessible by their key with
+// the new access grant.
+//
+//export uplink_access_disable_object_key_encryption
+func uplink_access_disable_object_key_encryption(access *C.UplinkAccess) C.UplinkAccessResult { //nolint:golint
+	if access == nil {
+		return C.UplinkAccessResult{
+			error: mallocError(ErrNull.New("access")),
+		}
+	}
+
+	acc, ok := universe.Get(access._handle).(*Access)
+	if !ok {
+		return C.UplinkAccessResult{
+			error: mallocError(ErrInvalidHandle.New("access")),
+		}
+	}
+
+	newAccess, err := disableObjectKeyEncryption(acc.Access)
+	if err != nil {
+		return C.UplinkAccessResult{
+			error: mallocError(err),
+		}
+	}
+	derived, err := acc.derive(newAccess)
+	if err != nil {
+		return C.UplinkAccessResult{
+			error: mallocError(err),
+		}
+	}
+
+	return C.UplinkAccessResult{
+		access: (*C.UplinkAccess)(mallocHandle(universe.Add(derived))),
+	}
+}
+
+// uplink_access_enable_path_encryption_bypass makes the access grant use
+// object keys as they are, without encrypting or decrypting them.
+//
+// The access grant format has no field for the setting, so it's not part of
+// uplink_access_serialize output and needs to be enabled again after
+// uplink_parse_access. Access grants created with uplink_access_share from
+// this access have it enabled.
+//
+//export uplink_access_enable_path_encryption_bypass
+func uplink_access_enable_path_encryption_bypass(access *C.UplinkAccess) *C.UplinkError {
+	if access == nil {
+		return mallocError(ErrNull.New("access"))
+	}
+
+	acc, ok := universe.Get(access._handle).(*Access)
+	if !ok {
+		return mallocError(ErrInvalidHandle.New("access"))
+	}
+
+	// projects opened with the current access must not change
+	g, err := toGrant(acc.Access)
+	if err != nil {
+		return mallocError(err)
+	}
+	newAccess, err := fromGrant(g)
+	if err != nil {
+		return mallocError(err)
+	}
+	if err := privateAccess.EnablePathEncryptionBypass(newAccess); err != nil {
+		return mallocError(err)
+	}
+
+	acc.Access = newAccess
+	acc.pathEncryptionBypass = true
+	return nil
+}
+
+// disableObjectKeyEncryption returns a copy of access, which doesn't encrypt
+// object keys.
+func disableObjectKeyEncryption(access *uplink.Access) (*uplink.Access, error) {
+	g, err := toGrant(access)
+	if err != nil {
+		return nil, err
+	}
+	g.EncAccess.SetDefaultPathCipher(storj.EncNull)
+	return fromGrant(g)
+}
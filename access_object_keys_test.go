@@ -0,0 +1,58 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/grant"
+	"storj.io/common/storj"
+	"storj.io/uplink"
+)
+
+func TestDisableObjectKeyEncryption(t *testing.T) {
+	access, err := disableObjectKeyEncryption(newTestAccess(t))
+	require.NoError(t, err)
+
+	parsed, err := uplink.ParseAccess(mustSerialize(t, access))
+	require.NoError(t, err)
+
+	shared, err := shareAccess(parsed, grant.Permission{AllowDownload: true},
+		[]grant.SharePrefix{{Bucket: "public", Prefix: "datasets/"}})
+	require.NoError(t, err)
+
+	g, err := toGrant(shared)
+	require.NoError(t, err)
+	require.Equal(t, storj.EncNull, g.EncAccess.Store.GetDefaultPathCipher())
+
+	inspection, err := inspectGrant(g, nil)
+	require.NoError(t, err)
+	require.True(t, inspection.UnencryptedObjectKeys)
+	require.Equal(t, []inspectedPrefix{{Bucket: "public", Prefix: "datasets"}}, inspection.Prefixes)
+
+	// the caveat uses the object key as it is
+	caveats, err := apiKeyCaveats(g.APIKey)
+	require.NoError(t, err)
+	require.Len(t, caveats, 1)
+	require.Len(t, caveats[0].AllowedPaths, 1)
+	require.Equal(t, "datasets", string(caveats[0].AllowedPaths[0].EncryptedPathPrefix))
+}
+
+func TestDerivePathEncryptionBypass(t *testing.T) {
+	acc := &Access{Access: newTestAccess(t), pathEncryptionBypass: true}
+
+	shared, err := shareAccess(acc.Access, grant.Permission{AllowList: true}, nil)
+	require.NoError(t, err)
+
+	derived, err := acc.derive(shared)
+	require.NoError(t, err)
+	require.True(t, derived.pathEncryptionBypass)
+	require.Equal(t, mustSerialize(t, shared), mustSerialize(t, derived.Access))
+
+	derived, err = (&Access{}).derive(shared)
+	require.NoError(t, err)
+	require.False(t, derived.pathEncryptionBypass)
+}
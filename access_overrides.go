@@ -0,0 +1,437 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+import (
+	"sort"
+	"strings"
+	"unsafe"
+
+	"storj.io/common/encryption"
+	"storj.io/common/grant"
+	"storj.io/common/paths"
+	"storj.io/common/storj"
+)
+
+// uplink_access_encryption_key_overrides lists the encryption key overrides
+// of the access grant, sorted by bucket and prefix.
+//
+// The prefixes end with "/" like the prefixes passed to
+// uplink_access_override_encryption_key.
+//
+// An override of a shared prefix is only known for accesses derived in this
+// process. After parsing a serialized access grant it cannot be told apart
+// from the key of the shared prefix and is not listed.
+//
+//export uplink_access_encryption_key_overrides
+func uplink_access_encryption_key_overrides(access *C.UplinkAccess) C.UplinkEncryptionKeyOverridesResult {
+	if access == nil {
+		return C.UplinkEncryptionKeyOverridesResult{
+			error: mallocError(ErrNull.New("access")),
+		}
+	}
+
+	acc, ok := universe.Get(access._handle).(*Access)
+	if !ok {
+		return C.UplinkEncryptionKeyOverridesResult{
+			error: mallocError(ErrInvalidHandle.New("access")),
+		}
+	}
+
+	g, err := toGrant(acc.Access)
+	if err != nil {
+		return C.UplinkEncryptionKeyOverridesResult{
+			error: mallocError(err),
+		}
+	}
+
+	overrides, err := grantOverrides(g, acc.overrides)
+	if err != nil {
+		return C.UplinkEncryptionKeyOverridesResult{
+			error: mallocError(err),
+		}
+	}
+
+	coverrides := (*C.UplinkEncryptionKeyOverride)(calloc(C.size_t(len(overrides)), C.sizeof_UplinkEncryptionKeyOverride))
+	array := unsafe.Slice(coverrides, len(overrides))
+	for i, override := range overrides {
+		prefix := override.Prefix
+		if prefix != "" {
+			prefix += "/"
+		}
+		array[i] = C.UplinkEncryptionKeyOverride{
+			bucket: cstring(override.Bucket),
+			prefix: cstring(prefix),
+		}
+	}
+
+	return C.UplinkEncryptionKeyOverridesResult{
+		overrides:       coverrides,
+		overrides_count: C.size_t(len(overrides)),
+	}
+}
+
+// uplink_free_encryption_key_overrides_result frees the resources associated with encryption key overrides result.
+//
+//export uplink_free_encryption_key_overrides_result
+func uplink_free_encryption_key_overrides_result(result C.UplinkEncryptionKeyOverridesResult) {
+	uplink_free_error(result.error)
+	if result.overrides == nil {
+		return
+	}
+	defer free(unsafe.Pointer(result.overrides))
+
+	array := unsafe.Slice(result.overrides, int(result.overrides_count))
+	for i := range array {
+		free(unsafe.Pointer(array[i].bucket))
+		free(unsafe.Pointer(array[i].prefix))
+	}
+}
+
+// uplink_access_remove_encryption_key_override removes the encryption key
+// override for the prefix in bucket.
+//
+//export uplink_access_remove_encryption_key_override
+func uplink_access_remove_encryption_key_override(access *C.UplinkAccess, bucket, prefix *C.uplink_const_char) *C.UplinkError { //nolint:golint
+	if access == nil {
+		return mallocError(ErrNull.New("access"))
+	}
+
+	acc, ok := universe.Get(access._handle).(*Access)
+	if !ok {
+		return mallocError(ErrInvalidHandle.New("access"))
+	}
+
+	target := overridePrefix(C.GoString(bucket), C.GoString(prefix))
+
+	g, err := toGrant(acc.Access)
+	if err != nil {
+		return mallocError(err)
+	}
+
+	existing, err := grantOverrides(g, acc.overrides)
+	if err != nil {
+		return mallocError(err)
+	}
+	if !containsPrefix(existing, target) {
+		return mallocError(ErrInvalidArg.New("no encryption key override for %q in %q", C.GoString(prefix), target.Bucket))
+	}
+
+	removed, err := removeOverrides(g, acc.overrides, func(override inspectedPrefix) bool {
+		return override == target
+	})
+	if err != nil {
+		return mallocError(err)
+	}
+	if len(removed) == 0 {
+		return mallocError(ErrInvalidArg.New("encryption key override for %q in %q is needed for the shared prefix", C.GoString(prefix), target.Bucket))
+	}
+
+	newAccess, err := fromGrant(g)
+	if err != nil {
+		return mallocError(err)
+	}
+	derived, err := acc.derive(newAccess)
+	if err != nil {
+		return mallocError(err)
+	}
+	delete(derived.overrides, target)
+	*acc = *derived
+	return nil
+}
+
+// uplink_access_share_with_overrides creates new access grant like
+// uplink_access_share, which only keeps the encryption key overrides listed
+// in overrides.
+//
+// Every entry of overrides must be an encryption key override of access.
+// Overrides outside of the shared prefixes are not part of the new access
+// grant, even when listed.
+//
+//export uplink_access_share_with_overrides
+func uplink_access_share_with_overrides(access *C.UplinkAccess, permission C.UplinkPermission, prefixes *C.UplinkSharePrefix, prefixes_count int, overrides *C.UplinkSharePrefix, overrides_count int) C.UplinkAccessResult { //nolint:golint
+	if access == nil {
+		return C.UplinkAccessResult{
+			error: mallocError(ErrNull.New("access")),
+		}
+	}
+
+	acc, ok := universe.Get(access._handle).(*Access)
+	if !ok {
+		return C.UplinkAccessResult{
+			error: mallocError(ErrInvalidHandle.New("access")),
+		}
+	}
+
+	perm, err := sharePermission(permission)
+	if err != nil {
+		return C.UplinkAccessResult{
+			error: mallocError(err),
+		}
+	}
+
+	var goprefixes []grant.SharePrefix
+	if prefixes != nil && prefixes_count > 0 {
+		for _, p := range unsafe.Slice(prefixes, prefixes_count) {
+			goprefixes = append(goprefixes, grant.SharePrefix{
+				Bucket: C.GoString(p.bucket),
+				Prefix: C.GoString(p.prefix),
+			})
+		}
+	}
+
+	g, err := toGrant(acc.Access)
+	if err != nil {
+		return C.UplinkAccessResult{
+			error: mallocError(err),
+		}
+	}
+	existing, err := grantOverrides(g, acc.overrides)
+	if err != nil {
+		return C.UplinkAccessResult{
+			error: mallocError(err),
+		}
+	}
+
+	keep := map[inspectedPrefix]bool{}
+	if overrides != nil && overrides_count > 0 {
+		for i, o := range unsafe.Slice(overrides, overrides_count) {
+			override := overridePrefix(C.GoString(o.bucket), C.GoString(o.prefix))
+			if !containsPrefix(existing, override) {
+				return C.UplinkAccessResult{
+					error: mallocError(ErrInvalidArg.New("overrides[%d]: no encryption key override for %q in %q", i, C.GoString(o.prefix), override.Bucket)),
+				}
+			}
+			keep[override] = true
+		}
+	}
+
+	// the access is shared before removing the overrides, so that the
+	// shared prefixes are encrypted with the same keys as in access.
+	shared, err := shareAccess(acc.Access, perm, goprefixes)
+	if err != nil {
+		return C.UplinkAccessResult{
+			error: mallocError(err),
+		}
+	}
+
+	sharedGrant, err := toGrant(shared)
+	if err != nil {
+		return C.UplinkAccessResult{
+			error: mallocError(err),
+		}
+	}
+	removed, err := removeOverrides(sharedGrant, acc.overrides, func(override inspectedPrefix) bool {
+		return !keep[override]
+	})
+	if err != nil {
+		return C.UplinkAccessResult{
+			error: mallocError(err),
+		}
+	}
+
+	restricted, err := fromGrant(sharedGrant)
+	if err != nil {
+		return C.UplinkAccessResult{
+			error: mallocError(err),
+		}
+	}
+	newAccess, err := acc.derive(restricted)
+	if err != nil {
+		return C.UplinkAccessResult{
+			error: mallocError(err),
+		}
+	}
+	for _, override := range removed {
+		delete(newAccess.overrides, override)
+	}
+	return C.UplinkAccessResult{
+		access: (*C.UplinkAccess)(mallocHandle(universe.Add(newAccess))),
+	}
+}
+
+// overridePrefix returns the bucket and prefix in the form used by the
+// encryption store.
+func overridePrefix(bucket, prefix string) inspectedPrefix {
+	return inspectedPrefix{Bucket: bucket, Prefix: strings.TrimSuffix(prefix, "/")}
+}
+
+// containsPrefix reports whether prefixes contains prefix.
+func containsPrefix(prefixes []inspectedPrefix, prefix inspectedPrefix) bool {
+	for _, p := range prefixes {
+		if p == prefix {
+			return true
+		}
+	}
+	return false
+}
+
+// sharedEncryptedPaths returns the encrypted paths the API key of the grant
+// is restricted to.
+func sharedEncryptedPaths(g *grant.Access) (map[inspectedPrefix]bool, error) {
+	caveats, err := apiKeyCaveats(g.APIKey)
+	if err != nil {
+		return nil, err
+	}
+
+	shared := map[inspectedPrefix]bool{}
+	for _, caveat := range caveats {
+		for _, path := range caveat.AllowedPaths {
+			shared[inspectedPrefix{Bucket: string(path.Bucket), Prefix: string(path.EncryptedPathPrefix)}] = true
+		}
+	}
+	return shared, nil
+}
+
+// storeEntry is an entry of an encryption store.
+type storeEntry struct {
+	bucket     string
+	unenc      paths.Unencrypted
+	enc        paths.Encrypted
+	key        storj.Key
+	pathCipher storj.CipherSuite
+}
+
+// prefix returns the bucket and unencrypted prefix of the entry.
+func (entry *storeEntry) prefix() inspectedPrefix {
+	return inspectedPrefix{Bucket: entry.bucket, Prefix: entry.unenc.Raw()}
+}
+
+// storeEntries returns the entries of store.
+func storeEntries(store *encryption.Store) ([]storeEntry, error) {
+	var entries []storeEntry
+	err := store.IterateWithCipher(func(bucket string, unenc paths.Unencrypted, enc paths.Encrypted, key storj.Key, pathCipher storj.CipherSuite) error {
+		entries = append(entries, storeEntry{bucket: bucket, unenc: unenc, enc: enc, key: key, pathCipher: pathCipher})
+		return nil
+	})
+	return entries, err
+}
+
+// newStoreWith returns a store with the settings of old and entries.
+func newStoreWith(old *encryption.Store, entries []storeEntry) (*encryption.Store, error) {
+	store := encryption.NewStore()
+	store.SetDefaultKey(old.GetDefaultKey())
+	store.SetDefaultPathCipher(old.GetDefaultPathCipher())
+	store.EncryptionBypass = old.EncryptionBypass
+
+	for _, entry := range entries {
+		if err := store.AddWithCipher(entry.bucket, entry.unenc, entry.enc, entry.key, entry.pathCipher); err != nil {
+			return nil, err
+		}
+	}
+	return store, nil
+}
+
+// overrideEntry describes whether an entry of the encryption store is an
+// encryption key override.
+type overrideEntry struct {
+	storeEntry
+	override bool
+	// required is set when the key cannot be derived without the entry.
+	required bool
+}
+
+// grantOverrideEntries classifies the entries of the encryption store of
+// the grant.
+//
+// When the key of an entry can be derived from the default key or another
+// entry, the entry is an override if its key differs from the derived key.
+// Otherwise the entry is an override if it is in tracked or is not a shared
+// path, because the encryption store of a restricted grant only contains
+// the shared paths besides the overrides.
+func grantOverrideEntries(g *grant.Access, tracked map[inspectedPrefix]bool) ([]overrideEntry, error) {
+	shared, err := sharedEncryptedPaths(g)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := storeEntries(g.EncAccess.Store)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]overrideEntry, 0, len(entries))
+	for i, entry := range entries {
+		others := append(append([]storeEntry{}, entries[:i]...), entries[i+1:]...)
+		without, err := newStoreWith(g.EncAccess.Store, others)
+		if err != nil {
+			return nil, err
+		}
+
+		classified := overrideEntry{storeEntry: entry}
+		derived, err := encryption.DerivePathKey(entry.bucket, entry.unenc, without)
+		if err == nil {
+			classified.override = *derived != entry.key
+		} else {
+			classified.required = true
+			classified.override = tracked[entry.prefix()] || !shared[inspectedPrefix{Bucket: entry.bucket, Prefix: entry.enc.Raw()}]
+		}
+		result = append(result, classified)
+	}
+	return result, nil
+}
+
+// grantOverrides returns the encryption key overrides of the grant, sorted by
+// bucket and unencrypted prefix. tracked are the overrides known to be added
+// to the access.
+func grantOverrides(g *grant.Access, tracked map[inspectedPrefix]bool) ([]inspectedPrefix, error) {
+	entries, err := grantOverrideEntries(g, tracked)
+	if err != nil {
+		return nil, err
+	}
+
+	var overrides []inspectedPrefix
+	for _, entry := range entries {
+		if entry.override {
+			overrides = append(overrides, entry.prefix())
+		}
+	}
+
+	sort.Slice(overrides, func(i, k int) bool {
+		if overrides[i].Bucket != overrides[k].Bucket {
+			return overrides[i].Bucket < overrides[k].Bucket
+		}
+		return overrides[i].Prefix < overrides[k].Prefix
+	})
+	return overrides, nil
+}
+
+// removeOverrides replaces the encryption store of the grant with one that
+// does not contain the overrides for which remove returns true. It returns
+// the removed overrides.
+//
+// Overrides of shared paths, whose key cannot be derived otherwise, are kept
+// because the shared paths could not be decrypted without them.
+func removeOverrides(g *grant.Access, tracked map[inspectedPrefix]bool, remove func(override inspectedPrefix) bool) ([]inspectedPrefix, error) {
+	shared, err := sharedEncryptedPaths(g)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := grantOverrideEntries(g, tracked)
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []inspectedPrefix
+	var kept []storeEntry
+	for _, entry := range entries {
+		sharedPath := shared[inspectedPrefix{Bucket: entry.bucket, Prefix: entry.enc.Raw()}]
+		if entry.override && !(entry.required && sharedPath) && remove(entry.prefix()) {
+			removed = append(removed, entry.prefix())
+			continue
+		}
+		kept = append(kept, entry.storeEntry)
+	}
+
+	store, err := newStoreWith(g.EncAccess.Store, kept)
+	if err != nil {
+		return nil, err
+	}
+
+	g.EncAccess = &grant.EncryptionAccess{Store: store}
+	return removed, nil
+}
@@ -0,0 +1,111 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/encryption"
+	"storj.io/common/grant"
+	"storj.io/common/paths"
+	"storj.io/common/testrand"
+)
+
+func TestEncryptionOverrides(t *testing.T) {
+	acc := &Access{Access: newTestAccess(t)}
+
+	keyA, keyB := testrand.Key(), testrand.Key()
+	require.NoError(t, acc.overrideEncryptionKey("beta", "tenant-b/", &keyB))
+	require.NoError(t, acc.overrideEncryptionKey("beta", "tenant-a/", &keyA))
+
+	original, err := toGrant(acc.Access)
+	require.NoError(t, err)
+
+	overrides, err := grantOverrides(original, acc.overrides)
+	require.NoError(t, err)
+	require.Equal(t, []inspectedPrefix{
+		{Bucket: "beta", Prefix: "tenant-a"},
+		{Bucket: "beta", Prefix: "tenant-b"},
+	}, overrides)
+
+	encryptPath := func(g *grant.Access, path string) string {
+		encPath, err := encryption.EncryptPathWithStoreCipher("beta", paths.NewUnencrypted(path), g.EncAccess.Store)
+		require.NoError(t, err)
+		return encPath.Raw()
+	}
+
+	// removing an override keeps the other keys
+	g, err := toGrant(acc.Access)
+	require.NoError(t, err)
+	removed, err := removeOverrides(g, acc.overrides, func(override inspectedPrefix) bool {
+		return override == overridePrefix("beta", "tenant-a/")
+	})
+	require.NoError(t, err)
+	require.Equal(t, []inspectedPrefix{{Bucket: "beta", Prefix: "tenant-a"}}, removed)
+
+	overrides, err = grantOverrides(g, acc.overrides)
+	require.NoError(t, err)
+	require.Equal(t, []inspectedPrefix{{Bucket: "beta", Prefix: "tenant-b"}}, overrides)
+	require.Equal(t, encryptPath(original, "tenant-b/file"), encryptPath(g, "tenant-b/file"))
+	require.NotEqual(t, encryptPath(original, "tenant-a/file"), encryptPath(g, "tenant-a/file"))
+
+	// the key of a shared prefix is kept when the overrides are removed
+	shared, err := shareAccess(acc.Access, grant.Permission{AllowDownload: true},
+		[]grant.SharePrefix{{Bucket: "beta", Prefix: "tenant-a/"}})
+	require.NoError(t, err)
+	g, err = toGrant(shared)
+	require.NoError(t, err)
+	_, err = removeOverrides(g, acc.overrides, func(inspectedPrefix) bool { return true })
+	require.NoError(t, err)
+	require.Equal(t, encryptPath(original, "tenant-a/file"), encryptPath(g, "tenant-a/file"))
+}
+
+func TestEncryptionOverridesOfSharedPrefixes(t *testing.T) {
+	acc := &Access{Access: newTestAccess(t)}
+
+	share := func(acc *Access, bucket, prefix string) *Access {
+		shared, err := shareAccess(acc.Access, grant.Permission{AllowDownload: true},
+			[]grant.SharePrefix{{Bucket: bucket, Prefix: prefix}})
+		require.NoError(t, err)
+		derived, err := acc.derive(shared)
+		require.NoError(t, err)
+		return derived
+	}
+	overrides := func(acc *Access) []inspectedPrefix {
+		g, err := toGrant(acc.Access)
+		require.NoError(t, err)
+		overrides, err := grantOverrides(g, acc.overrides)
+		require.NoError(t, err)
+		return overrides
+	}
+
+	// shared prefixes without overrides, also when the caveats are nested
+	plain := share(acc, "alpha", "photos/")
+	require.Empty(t, overrides(plain))
+	require.Empty(t, overrides(share(plain, "alpha", "photos/2020/")))
+
+	// an override of a prefix which is also shared
+	key := testrand.Key()
+	overridden := &Access{Access: newTestAccess(t)}
+	require.NoError(t, overridden.overrideEncryptionKey("beta", "tenant/", &key))
+	require.Equal(t, []inspectedPrefix{{Bucket: "beta", Prefix: "tenant"}}, overrides(overridden))
+
+	shared := share(overridden, "beta", "tenant/")
+	require.Equal(t, []inspectedPrefix{{Bucket: "beta", Prefix: "tenant"}}, overrides(shared))
+
+	// sharing first and overriding the shared prefix afterwards
+	later := share(&Access{Access: newTestAccess(t)}, "beta", "tenant/")
+	require.Empty(t, overrides(later))
+	require.NoError(t, later.overrideEncryptionKey("beta", "tenant/", &key))
+	require.Equal(t, []inspectedPrefix{{Bucket: "beta", Prefix: "tenant"}}, overrides(later))
+
+	// the override of the shared prefix is needed for decrypting it
+	g, err := toGrant(shared.Access)
+	require.NoError(t, err)
+	removed, err := removeOverrides(g, shared.overrides, func(inspectedPrefix) bool { return true })
+	require.NoError(t, err)
+	require.Empty(t, removed)
+}
@@ -0,0 +1,187 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+import (
+	"bytes"
+
+	"storj.io/common/grant"
+	"storj.io/common/macaroon"
+)
+
+// subsetActions are the API key actions compared by uplink_access_is_subset.
+var subsetActions = []macaroon.ActionType{
+	macaroon.ActionRead,
+	macaroon.ActionWrite,
+	macaroon.ActionList,
+	macaroon.ActionDelete,
+	macaroon.ActionLock,
+	macaroon.ActionPutObjectRetention,
+	macaroon.ActionGetObjectRetention,
+	macaroon.ActionPutObjectLegalHold,
+	macaroon.ActionGetObjectLegalHold,
+	macaroon.ActionBypassGovernanceRetention,
+	macaroon.ActionPutBucketObjectLockConfiguration,
+	macaroon.ActionGetBucketObjectLockConfiguration,
+	macaroon.ActionPutBucketNotificationConfiguration,
+	macaroon.ActionGetBucketNotificationConfiguration,
+}
+
+// uplink_access_is_subset reports whether access a grants nothing beyond
+// access b.
+//
+// Only the API keys are compared, without contacting the satellite. The
+// accesses must be for the same satellite and API key. The permissions, the
+// time window, the max object TTL and the prefixes of a must all be within
+// the ones of b. An access created with uplink_access_share is a subset of
+// the access it was shared from.
+//
+// The check is conservative, it can report false for caveats that restrict
+// a in a way that cannot be compared with b.
+//
+//export uplink_access_is_subset
+func uplink_access_is_subset(a, b *C.UplinkAccess) C.UplinkBoolResult {
+	if a == nil {
+		return C.UplinkBoolResult{
+			error: mallocError(ErrNull.New("a")),
+		}
+	}
+	if b == nil {
+		return C.UplinkBoolResult{
+			error: mallocError(ErrNull.New("b")),
+		}
+	}
+
+	accA, ok := universe.Get(a._handle).(*Access)
+	if !ok {
+		return C.UplinkBoolResult{
+			error: mallocError(ErrInvalidHandle.New("a")),
+		}
+	}
+	accB, ok := universe.Get(b._handle).(*Access)
+	if !ok {
+		return C.UplinkBoolResult{
+			error: mallocError(ErrInvalidHandle.New("b")),
+		}
+	}
+
+	grantA, err := toGrant(accA.Access)
+	if err != nil {
+		return C.UplinkBoolResult{
+			error: mallocError(err),
+		}
+	}
+	grantB, err := toGrant(accB.Access)
+	if err != nil {
+		return C.UplinkBoolResult{
+			error: mallocError(err),
+		}
+	}
+
+	subset, err := isSubset(grantA, grantB)
+	if err != nil {
+		return C.UplinkBoolResult{
+			error: mallocError(err),
+		}
+	}
+
+	return C.UplinkBoolResult{
+		value: C.bool(subset),
+	}
+}
+
+// uplink_free_bool_result frees the resources associated with bool result.
+//
+//export uplink_free_bool_result
+func uplink_free_bool_result(result C.UplinkBoolResult) {
+	uplink_free_error(result.error)
+}
+
+// isSubset reports whether a grants nothing beyond b.
+func isSubset(a, b *grant.Access) (bool, error) {
+	if a.SatelliteAddress != b.SatelliteAddress {
+		return false, nil
+	}
+	if !bytes.Equal(a.APIKey.Head(), b.APIKey.Head()) {
+		return false, nil
+	}
+
+	caveatsA, err := apiKeyCaveats(a.APIKey)
+	if err != nil {
+		return false, err
+	}
+	caveatsB, err := apiKeyCaveats(b.APIKey)
+	if err != nil {
+		return false, err
+	}
+
+	for _, action := range subsetActions {
+		if actionAllowed(caveatsA, action) && !actionAllowed(caveatsB, action) {
+			return false, nil
+		}
+	}
+
+	notBeforeA, notAfterA, ttlA := caveatLimits(caveatsA)
+	notBeforeB, notAfterB, ttlB := caveatLimits(caveatsB)
+	if notBeforeB != nil && (notBeforeA == nil || notBeforeA.Before(*notBeforeB)) {
+		return false, nil
+	}
+	if notAfterB != nil && (notAfterA == nil || notAfterA.After(*notAfterB)) {
+		return false, nil
+	}
+	if ttlB != nil && (ttlA == nil || *ttlA > *ttlB) {
+		return false, nil
+	}
+
+	// every path restriction of b must be implied by a path restriction of a.
+	for _, caveatB := range caveatsB {
+		if len(caveatB.AllowedPaths) == 0 {
+			continue
+		}
+
+		implied := false
+		for _, caveatA := range caveatsA {
+			if len(caveatA.AllowedPaths) > 0 && pathsCovered(caveatA.AllowedPaths, caveatB.AllowedPaths) {
+				implied = true
+				break
+			}
+		}
+		if !implied {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// actionAllowed reports whether the caveats allow the action on an object,
+// ignoring the time window and the paths.
+func actionAllowed(caveats []macaroon.Caveat, op macaroon.ActionType) bool {
+	for _, caveat := range caveats {
+		caveat.NotBefore, caveat.NotAfter, caveat.AllowedPaths = nil, nil, nil
+		if !caveat.Allows(macaroon.Action{Op: op, EncryptedPath: []byte("object")}) {
+			return false
+		}
+	}
+	return true
+}
+
+// pathsCovered reports whether every path is within one of the allowed paths.
+func pathsCovered(paths, allowed []*macaroon.Caveat_Path) bool {
+	for _, path := range paths {
+		covered := false
+		for _, other := range allowed {
+			if bytes.Equal(path.Bucket, other.Bucket) && bytes.HasPrefix(path.EncryptedPathPrefix, other.EncryptedPathPrefix) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/grant"
+	"storj.io/uplink"
+)
+
+func TestIsSubset(t *testing.T) {
+	root := newTestAccess(t)
+	notAfter := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	share := func(t *testing.T, parent *uplink.Access, perm grant.Permission, prefixes ...grant.SharePrefix) *uplink.Access {
+		shared, err := shareAccess(parent, perm, prefixes)
+		require.NoError(t, err)
+		return shared
+	}
+	subset := func(t *testing.T, a, b *uplink.Access) bool {
+		grantA, err := toGrant(a)
+		require.NoError(t, err)
+		grantB, err := toGrant(b)
+		require.NoError(t, err)
+		ok, err := isSubset(grantA, grantB)
+		require.NoError(t, err)
+		return ok
+	}
+
+	parent := share(t, root, grant.Permission{
+		AllowDownload: true,
+		AllowList:     true,
+		NotAfter:      notAfter,
+	}, grant.SharePrefix{Bucket: "alpha", Prefix: "tenant/"})
+
+	require.True(t, subset(t, root, root))
+	require.True(t, subset(t, parent, root))
+	require.False(t, subset(t, root, parent))
+
+	child := share(t, parent, grant.Permission{
+		AllowDownload: true,
+		NotAfter:      notAfter.Add(-time.Minute),
+	}, grant.SharePrefix{Bucket: "alpha", Prefix: "tenant/photos/"})
+	require.True(t, subset(t, child, parent))
+	require.False(t, subset(t, parent, child))
+
+	// same restrictions, but created from root
+	sibling := share(t, root, grant.Permission{
+		AllowDownload: true,
+		NotAfter:      notAfter,
+	}, grant.SharePrefix{Bucket: "alpha", Prefix: "tenant/photos/"})
+	require.True(t, subset(t, sibling, parent))
+
+	writer := share(t, root, grant.Permission{
+		AllowDownload: true,
+		AllowUpload:   true,
+		NotAfter:      notAfter,
+	}, grant.SharePrefix{Bucket: "alpha", Prefix: "tenant/photos/"})
+	require.False(t, subset(t, writer, parent))
+
+	longer := share(t, root, grant.Permission{
+		AllowDownload: true,
+		NotAfter:      notAfter.Add(time.Minute),
+	}, grant.SharePrefix{Bucket: "alpha", Prefix: "tenant/photos/"})
+	require.False(t, subset(t, longer, parent))
+
+	otherBucket := share(t, root, grant.Permission{
+		AllowDownload: true,
+		NotAfter:      notAfter,
+	}, grant.SharePrefix{Bucket: "beta", Prefix: "tenant/"})
+	require.False(t, subset(t, otherBucket, parent))
+
+	require.False(t, subset(t, newTestAccess(t), root))
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/encryption"
+	"storj.io/common/grant"
+	"storj.io/common/macaroon"
+	"storj.io/common/storj"
+	"storj.io/common/testrand"
+	"storj.io/uplink"
+)
+
+func TestNewAccess(t *testing.T) {
+	const satelliteAddress = "1111111111111111111111111111111VyS547o@127.0.0.1:7777"
+
+	apiKey, err := macaroon.NewAPIKey(testrand.BytesInt(32))
+	require.NoError(t, err)
+
+	key, err := encryption.DeriveRootKey([]byte("passphrase"), []byte("salt"), "", 1)
+	require.NoError(t, err)
+
+	access, err := newAccess(satelliteAddress, apiKey.Serialize(), key)
+	require.NoError(t, err)
+	require.Equal(t, satelliteAddress, access.SatelliteAddress())
+
+	g, err := toGrant(access)
+	require.NoError(t, err)
+	require.Equal(t, apiKey.Serialize(), g.APIKey.Serialize())
+	require.Equal(t, key, g.EncAccess.Store.GetDefaultKey())
+	require.Equal(t, storj.EncAESGCM, g.EncAccess.Store.GetDefaultPathCipher())
+
+	_, err = newAccess("", apiKey.Serialize(), key)
+	require.True(t, ErrInvalidArg.Has(err))
+
+	_, err = newAccess(satelliteAddress, "invalid", key)
+	require.True(t, ErrInvalidArg.Has(err))
+}
+
+func TestOverrideEncryptionKey(t *testing.T) {
+	access := newTestAccess(t)
+	expected, err := uplink.ParseAccess(mustSerialize(t, access))
+	require.NoError(t, err)
+
+	overrideKey, err := encryption.DeriveRootKey([]byte("override"), []byte("salt"), "", 1)
+	require.NoError(t, err)
+	expectedKey, err := uplink.DeriveEncryptionKey("override", []byte("salt"))
+	require.NoError(t, err)
+
+	acc := &Access{Access: access}
+	require.Error(t, acc.overrideEncryptionKey("beta", "tenant", overrideKey))
+	require.NoError(t, acc.overrideEncryptionKey("beta", "tenant/", overrideKey))
+	require.NoError(t, expected.OverrideEncryptionKey("beta", "tenant/", expectedKey))
+
+	require.Equal(t, mustSerialize(t, expected), mustSerialize(t, acc.Access))
+}
+
+func mustSerialize(t *testing.T, access *uplink.Access) string {
+	serialized, err := access.Serialize()
+	require.NoError(t, err)
+	return serialized
+}
+
+func TestShareAccess(t *testing.T) {
+	access := newTestAccess(t)
+
+	ttl := time.Hour
+	shared, err := shareAccess(access, grant.Permission{
+		AllowUpload:                    true,
+		AllowDelete:                    true,
+		AllowPutObjectRetention:        true,
+		AllowGetObjectLegalHold:        true,
+		AllowBypassGovernanceRetention: true,
+		MaxObjectTTL:                   &ttl,
+	}, []grant.SharePrefix{{Bucket: "alpha", Prefix: "ingest/"}})
+	require.NoError(t, err)
+
+	g, err := toGrant(shared)
+	require.NoError(t, err)
+	inspection, err := inspectGrant(g, nil)
+	require.NoError(t, err)
+
+	permission := inspection.Permission
+	require.True(t, permission.AllowUpload)
+	require.True(t, permission.AllowDelete)
+	require.True(t, permission.AllowPutObjectRetention)
+	require.True(t, permission.AllowGetObjectLegalHold)
+	require.True(t, permission.AllowBypassGovernanceRetention)
+	require.False(t, permission.AllowDownload)
+	require.False(t, permission.AllowPutObjectLegalHold)
+	require.NotNil(t, permission.MaxObjectTTLSeconds)
+	require.EqualValues(t, 3600, *permission.MaxObjectTTLSeconds)
+	require.Equal(t, []inspectedPrefix{{Bucket: "alpha", Prefix: "ingest"}}, inspection.Prefixes)
+
+	_, err = shareAccess(access, grant.Permission{}, nil)
+	require.Error(t, err)
+}
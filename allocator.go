@@ -0,0 +1,37 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+
+// uplink_set_allocator sets the functions used to allocate and free all the
+// memory that is returned to the caller: results, strings, metadata arrays and
+// handle wrappers. Passing NULL restores the libc allocator.
+//
+// The uplink_free_* functions release memory with the allocator that is set
+// at the time of the call, so the allocator should be set before any other
+// function is called and must not be changed while results are outstanding.
+//
+//export uplink_set_allocator
+func uplink_set_allocator(custom *C.UplinkAllocator) *C.UplinkError {
+	if custom == nil {
+		allocator.Store(nil)
+		return nil
+	}
+
+	if custom.malloc_fn == nil {
+		return mallocError(ErrNull.New("malloc_fn"))
+	}
+	if custom.free_fn == nil {
+		return mallocError(ErrNull.New("free_fn"))
+	}
+
+	allocator.Store(&C.UplinkAllocator{
+		malloc_fn: custom.malloc_fn,
+		calloc_fn: custom.calloc_fn,
+		free_fn:   custom.free_fn,
+	})
+	return nil
+}
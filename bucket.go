@@ -33,7 +33,11 @@ func uplink_stat_bucket(project *C.UplinkProject, bucket_name *C.uplink_const_ch
 		}
 	}
 
-	bucket, err := proj.StatBucket(proj.scope.ctx, C.GoString(bucket_name))
+	ctx := proj.scope.ctx
+	span := proj.startSpan(&ctx, "uplink_stat_bucket", C.GoString(bucket_name), "")
+	bucket, err := proj.StatBucket(ctx, C.GoString(bucket_name))
+	span.finish(err)
+	proj.stats.record(C.UPLINK_OPERATION_STAT_BUCKET, err)
 
 	return C.UplinkBucketResult{
 		error:  mallocError(err),
@@ -65,7 +69,11 @@ func uplink_create_bucket(project *C.UplinkProject, bucket_name *C.uplink_const_
 		}
 	}
 
-	bucket, err := proj.CreateBucket(proj.scope.ctx, C.GoString(bucket_name))
+	ctx := proj.scope.ctx
+	span := proj.startSpan(&ctx, "uplink_create_bucket", C.GoString(bucket_name), "")
+	bucket, err := proj.CreateBucket(ctx, C.GoString(bucket_name))
+	span.finish(err)
+	proj.stats.record(C.UPLINK_OPERATION_CREATE_BUCKET, err)
 
 	return C.UplinkBucketResult{
 		error:  mallocError(err),
@@ -97,7 +105,11 @@ func uplink_ensure_bucket(project *C.UplinkProject, bucket_name *C.uplink_const_
 		}
 	}
 
-	bucket, err := proj.EnsureBucket(proj.scope.ctx, C.GoString(bucket_name))
+	ctx := proj.scope.ctx
+	span := proj.startSpan(&ctx, "uplink_ensure_bucket", C.GoString(bucket_name), "")
+	bucket, err := proj.EnsureBucket(ctx, C.GoString(bucket_name))
+	span.finish(err)
+	proj.stats.record(C.UPLINK_OPERATION_ENSURE_BUCKET, err)
 
 	return C.UplinkBucketResult{
 		error:  mallocError(err),
@@ -129,7 +141,11 @@ func uplink_delete_bucket(project *C.UplinkProject, bucket_name *C.uplink_const_
 		}
 	}
 
-	deleted, err := proj.DeleteBucket(proj.scope.ctx, C.GoString(bucket_name))
+	ctx := proj.scope.ctx
+	span := proj.startSpan(&ctx, "uplink_delete_bucket", C.GoString(bucket_name), "")
+	deleted, err := proj.DeleteBucket(ctx, C.GoString(bucket_name))
+	span.finish(err)
+	proj.stats.record(C.UPLINK_OPERATION_DELETE_BUCKET, err)
 	return C.UplinkBucketResult{
 		error:  mallocError(err),
 		bucket: mallocBucket(deleted),
@@ -160,7 +176,11 @@ func uplink_delete_bucket_with_objects(project *C.UplinkProject, bucket_name *C.
 		}
 	}
 
-	deleted, err := proj.DeleteBucketWithObjects(proj.scope.ctx, C.GoString(bucket_name))
+	ctx := proj.scope.ctx
+	span := proj.startSpan(&ctx, "uplink_delete_bucket_with_objects", C.GoString(bucket_name), "")
+	deleted, err := proj.DeleteBucketWithObjects(ctx, C.GoString(bucket_name))
+	span.finish(err)
+	proj.stats.record(C.UPLINK_OPERATION_DELETE_BUCKET_WITH_OBJECTS, err)
 	return C.UplinkBucketResult{
 		error:  mallocError(err),
 		bucket: mallocBucket(deleted),
@@ -173,7 +193,7 @@ func mallocBucket(bucket *uplink.Bucket) *C.UplinkBucket {
 	}
 
 	cbucket := (*C.UplinkBucket)(calloc(1, C.sizeof_UplinkBucket))
-	cbucket.name = C.CString(bucket.Name)
+	cbucket.name = cstring(bucket.Name)
 	cbucket.created = timeToUnix(bucket.Created)
 
 	return cbucket
@@ -194,9 +214,9 @@ func uplink_free_bucket(bucket *C.UplinkBucket) {
 	if bucket == nil {
 		return
 	}
-	defer C.free(unsafe.Pointer(bucket))
+	defer free(unsafe.Pointer(bucket))
 
 	if bucket.name != nil {
-		C.free(unsafe.Pointer(bucket.name))
+		free(unsafe.Pointer(bucket.name))
 	}
 }
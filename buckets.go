@@ -41,7 +41,10 @@ func uplink_list_buckets(project *C.UplinkProject, options *C.UplinkListBucketsO
 	}
 
 	scope := proj.scope.child()
+	span := proj.startSpan(&scope.ctx, "uplink_list_buckets", "", "")
 	iterator := proj.ListBuckets(scope.ctx, opts)
+	span.finish(nil)
+	proj.stats.record(C.UPLINK_OPERATION_LIST_BUCKETS, nil)
 	return (*C.UplinkBucketIterator)(mallocHandle(universe.Add(&BucketIterator{
 		scope:    scope,
 		iterator: iterator,
@@ -114,7 +117,7 @@ func uplink_free_bucket_iterator(iterator *C.UplinkBucketIterator) {
 	if iterator == nil {
 		return
 	}
-	defer C.free(unsafe.Pointer(iterator))
+	defer free(unsafe.Pointer(iterator))
 	defer universe.Del(iterator._handle)
 
 	iter, ok := universe.Get(iterator._handle).(*BucketIterator)
@@ -0,0 +1,56 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// cgo cannot call C function pointers directly, so the functions below
+// forward the calls to the callbacks registered by the caller.
+//
+// This file must not contain any //export, since the preamble has definitions.
+
+/*
+#include "uplink_definitions.h"
+
+static void uplink_call_log_callback(UplinkLogCallback callback, int32_t level, const char *message,
+                                     const UplinkLogField *fields, size_t fields_count, void *user_data) {
+	callback(level, message, fields, fields_count, user_data);
+}
+
+static void uplink_call_metric_callback(UplinkMetricCallback callback, const char *series, const char *field,
+                                        double value, void *user_data) {
+	callback(series, field, value, user_data);
+}
+
+static void uplink_call_span_callback(UplinkSpanCallback callback, int32_t event, const UplinkSpan *span,
+                                      void *user_data) {
+	callback(event, span, user_data);
+}
+
+static void uplink_call_delete_prefix_callback(UplinkDeletePrefixCallback callback,
+                                               const UplinkDeletePrefixProgress *progress, const char *key,
+                                               int32_t error_code, void *user_data) {
+	callback(progress, key, error_code, user_data);
+}
+*/
+import "C"
+import "unsafe"
+
+// callLogCallback calls the log callback.
+func callLogCallback(callback C.UplinkLogCallback, level C.int32_t, message *C.char, fields *C.UplinkLogField, count C.size_t, userData unsafe.Pointer) {
+	C.uplink_call_log_callback(callback, level, message, fields, count, userData)
+}
+
+// callMetricCallback calls the metric callback.
+func callMetricCallback(callback C.UplinkMetricCallback, series, field *C.char, value C.double, userData unsafe.Pointer) {
+	C.uplink_call_metric_callback(callback, series, field, value, userData)
+}
+
+// callSpanCallback calls the span callback.
+func callSpanCallback(callback C.UplinkSpanCallback, event C.int32_t, span *C.UplinkSpan, userData unsafe.Pointer) {
+	C.uplink_call_span_callback(callback, event, span, userData)
+}
+
+// callDeletePrefixCallback calls the delete prefix progress callback.
+func callDeletePrefixCallback(callback C.UplinkDeletePrefixCallback, progress *C.UplinkDeletePrefixProgress, key *C.char, code C.int32_t, userData unsafe.Pointer) {
+	C.uplink_call_delete_prefix_callback(callback, progress, key, code, userData)
+}
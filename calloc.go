@@ -3,16 +3,55 @@
 
 package main
 
-// #include <stdlib.h>
+/*
+#include <stdlib.h>
+#include <string.h>
+
+#include "uplink_definitions.h"
+
+static void *uplink_allocator_malloc(UplinkAllocator *allocator, size_t size) {
+	return allocator->malloc_fn(size);
+}
+
+static void *uplink_allocator_calloc(UplinkAllocator *allocator, size_t nitems, size_t size) {
+	if (allocator->calloc_fn != NULL) {
+		return allocator->calloc_fn(nitems, size);
+	}
+	if (size != 0 && nitems > SIZE_MAX / size) {
+		return NULL;
+	}
+	void *ptr = allocator->malloc_fn(nitems * size);
+	if (ptr != NULL) {
+		memset(ptr, 0, nitems * size);
+	}
+	return ptr;
+}
+
+static void uplink_allocator_free(UplinkAllocator *allocator, void *ptr) {
+	allocator->free_fn(ptr);
+}
+*/
 import "C"
 
-import "unsafe"
+import (
+	"sync/atomic"
+	"unsafe"
+)
 
 //go:linkname calloc_runtime_throw runtime.throw
 func calloc_runtime_throw(string)
 
+// allocator is the custom allocator set by uplink_set_allocator, nil when
+// libc functions should be used.
+var allocator atomic.Pointer[C.UplinkAllocator]
+
 func calloc(nitems C.size_t, size C.size_t) unsafe.Pointer {
-	ptr := C.calloc(nitems, size)
+	var ptr unsafe.Pointer
+	if custom := allocator.Load(); custom != nil {
+		ptr = C.uplink_allocator_calloc(custom, nitems, size)
+	} else {
+		ptr = C.calloc(nitems, size)
+	}
 
 	if ptr == nil {
 		// if requested a zero-sized allocation and a nil pointer
@@ -20,7 +59,7 @@ func calloc(nitems C.size_t, size C.size_t) unsafe.Pointer {
 		// of the Go provided malloc wrapper in that it never
 		// returns a nil pointer.
 		if nitems == 0 || size == 0 {
-			return C.malloc(1)
+			return malloc(1)
 		}
 		calloc_runtime_throw("runtime: C calloc failed")
 		panic("unreachable")
@@ -28,3 +67,39 @@ func calloc(nitems C.size_t, size C.size_t) unsafe.Pointer {
 
 	return ptr
 }
+
+func malloc(size C.size_t) unsafe.Pointer {
+	var ptr unsafe.Pointer
+	if custom := allocator.Load(); custom != nil {
+		ptr = C.uplink_allocator_malloc(custom, size)
+	} else {
+		ptr = C.malloc(size)
+	}
+
+	if ptr == nil {
+		calloc_runtime_throw("runtime: C malloc failed")
+		panic("unreachable")
+	}
+
+	return ptr
+}
+
+// cstring is a replacement for C.CString that uses the configured allocator.
+func cstring(s string) *C.char {
+	ptr := malloc(C.size_t(len(s) + 1))
+
+	buf := unsafe.Slice((*byte)(ptr), len(s)+1)
+	copy(buf, s)
+	buf[len(s)] = 0
+
+	return (*C.char)(ptr)
+}
+
+// free releases memory allocated with calloc, malloc or cstring.
+func free(ptr unsafe.Pointer) {
+	if custom := allocator.Load(); custom != nil {
+		C.uplink_allocator_free(custom, ptr)
+		return
+	}
+	C.free(ptr)
+}
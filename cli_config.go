@@ -0,0 +1,316 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unsafe"
+
+	"storj.io/uplink"
+)
+
+// cliConfig is the configuration of the uplink CLI.
+type cliConfig struct {
+	DefaultAccess string
+	Accesses      map[string]string
+	// Settings contains the remaining settings with dotted keys, e.g.
+	// "client.user-agent".
+	Settings map[string]string
+}
+
+// uplink_import_cli_config loads an access and settings from the
+// configuration directory of the uplink CLI.
+//
+// When config_dir is NULL, UPLINK_CONFIG_DIR or the default directory of the
+// CLI is used. When access_name is NULL or empty, the default access of the
+// CLI is used. access_name can also be a serialized access grant.
+//
+// The accesses are read from access.json, or from config.yaml of older CLI
+// versions. The returned config is a copy of base with the client.user-agent
+// and client.dial-timeout settings of the CLI applied. The other settings of
+// the CLI, e.g. metrics.addr, are ignored, since they configure the CLI
+// itself rather than uplink. The result must be freed with
+// uplink_free_cli_config_result.
+//
+//export uplink_import_cli_config
+func uplink_import_cli_config(base C.UplinkConfig, config_dir, access_name *C.uplink_const_char) C.UplinkCLIConfigResult {
+	dir := ""
+	if config_dir != nil {
+		dir = C.GoString(config_dir)
+	}
+	if dir == "" {
+		var err error
+		dir, err = defaultCLIConfigDir()
+		if err != nil {
+			return C.UplinkCLIConfigResult{
+				error: mallocError(err),
+			}
+		}
+	}
+
+	name := ""
+	if access_name != nil {
+		name = C.GoString(access_name)
+	}
+
+	cfg, err := readCLIConfig(dir)
+	if err != nil {
+		return C.UplinkCLIConfigResult{
+			error: mallocError(err),
+		}
+	}
+
+	serialized, err := cfg.access(name)
+	if err != nil {
+		return C.UplinkCLIConfigResult{
+			error: mallocError(err),
+		}
+	}
+
+	config, err := cfg.apply(uplinkConfig(base))
+	if err != nil {
+		return C.UplinkCLIConfigResult{
+			error: mallocError(err),
+		}
+	}
+
+	access, err := uplink.ParseAccess(serialized)
+	if err != nil {
+		return C.UplinkCLIConfigResult{
+			error: mallocError(err),
+		}
+	}
+
+	result := C.UplinkCLIConfigResult{
+		access: (*C.UplinkAccess)(mallocHandle(universe.Add(&Access{Access: access}))),
+	}
+	result.config.user_agent = cstring(config.UserAgent)
+	result.config.dial_timeout_milliseconds = C.int32_t(config.DialTimeout / time.Millisecond)
+	if base.temp_directory != nil {
+		result.config.temp_directory = cstring(C.GoString(base.temp_directory))
+	}
+	return result
+}
+
+// uplink_free_cli_config_result frees the resources associated with cli config result.
+//
+//export uplink_free_cli_config_result
+func uplink_free_cli_config_result(result C.UplinkCLIConfigResult) {
+	uplink_free_error(result.error)
+	freeAccess(result.access)
+	free(unsafe.Pointer(result.config.user_agent))
+	free(unsafe.Pointer(result.config.temp_directory))
+}
+
+// defaultCLIConfigDir returns the configuration directory used by the CLI.
+func defaultCLIConfigDir() (string, error) {
+	if dir := os.Getenv("UPLINK_CONFIG_DIR"); dir != "" {
+		return dir, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "storj", "uplink"), nil
+}
+
+// readCLIConfig reads the configuration files of the CLI in dir.
+func readCLIConfig(dir string) (*cliConfig, error) {
+	cfg := &cliConfig{
+		Accesses: map[string]string{},
+		Settings: map[string]string{},
+	}
+
+	found := false
+	for _, file := range []struct {
+		name  string
+		parse func([]byte) (map[string]string, error)
+	}{
+		{"config.yaml", parseLegacyCLIConfig},
+		{"config.ini", parseCLIConfigINI},
+	} {
+		data, err := os.ReadFile(filepath.Join(dir, file.name))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		found = true
+
+		values, err := file.parse(data)
+		if err != nil {
+			return nil, ErrInvalidArg.New("%s: %v", file.name, err)
+		}
+		for key, value := range values {
+			switch {
+			case key == "access":
+				cfg.DefaultAccess = value
+			case strings.HasPrefix(key, "accesses."):
+				cfg.Accesses[strings.TrimPrefix(key, "accesses.")] = value
+			default:
+				cfg.Settings[key] = value
+			}
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "access.json"))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+	case err != nil:
+		return nil, err
+	default:
+		found = true
+
+		var accessInfo struct {
+			Default  string            `json:"default"`
+			Accesses map[string]string `json:"accesses"`
+		}
+		if err := json.Unmarshal(data, &accessInfo); err != nil {
+			return nil, ErrInvalidArg.New("access.json: %v", err)
+		}
+		if accessInfo.Default != "" {
+			cfg.DefaultAccess = accessInfo.Default
+		}
+		for name, access := range accessInfo.Accesses {
+			cfg.Accesses[name] = access
+		}
+	}
+
+	if !found {
+		return nil, ErrInvalidArg.New("no uplink configuration in %q", dir)
+	}
+	return cfg, nil
+}
+
+// access returns the serialized access with name, or the default access when
+// name is empty.
+func (cfg *cliConfig) access(name string) (string, error) {
+	if name == "" {
+		name = cfg.DefaultAccess
+		if name == "" {
+			return "", ErrInvalidArg.New("no default access")
+		}
+	}
+
+	if access, ok := cfg.Accesses[name]; ok {
+		return access, nil
+	}
+	// the CLI also accepts a serialized access instead of a name.
+	if _, err := uplink.ParseAccess(name); err == nil {
+		return name, nil
+	}
+	return "", ErrInvalidArg.New("access %q not found", name)
+}
+
+// apply returns config with the client.user-agent and client.dial-timeout
+// settings applied. The other settings have no counterpart in uplink.Config
+// and are ignored.
+func (cfg *cliConfig) apply(config uplink.Config) (uplink.Config, error) {
+	if userAgent, ok := cfg.Settings["client.user-agent"]; ok {
+		config.UserAgent = userAgent
+	}
+	if value, ok := cfg.Settings["client.dial-timeout"]; ok {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return config, ErrInvalidArg.New("client.dial-timeout: %v", err)
+		}
+		config.DialTimeout = timeout
+	}
+	return config, nil
+}
+
+// parseCLIConfigINI parses the config.ini of the CLI. Keys in a section are
+// prefixed with the section name.
+func parseCLIConfigINI(data []byte) (map[string]string, error) {
+	values := map[string]string{}
+	section := ""
+
+	for i, line := range cliConfigLines(data) {
+		lineNumber := i + 1
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "", strings.HasPrefix(line, "#"), strings.HasPrefix(line, ";"):
+			continue
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: missing '='", lineNumber)
+		}
+		key = strings.TrimSpace(key)
+		if section != "" {
+			key = section + "." + key
+		}
+		values[key] = unquote(strings.TrimSpace(value))
+	}
+	return values, nil
+}
+
+// parseLegacyCLIConfig parses the config.yaml of older CLI versions. Only
+// "key: value" lines and one level of nested keys are supported, which is
+// what the CLI writes.
+func parseLegacyCLIConfig(data []byte) (map[string]string, error) {
+	values := map[string]string{}
+	parent := ""
+
+	for i, raw := range cliConfigLines(data) {
+		lineNumber := i + 1
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: missing ':'", lineNumber)
+		}
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+
+		nested := raw[0] == ' ' || raw[0] == '\t'
+		switch {
+		case !nested && value == "":
+			parent = key
+		case nested:
+			if parent == "" {
+				return nil, fmt.Errorf("line %d: unexpected indentation", lineNumber)
+			}
+			values[parent+"."+unquote(key)] = unquote(value)
+		default:
+			parent = ""
+			values[key] = unquote(value)
+		}
+	}
+	return values, nil
+}
+
+// cliConfigLines splits data into lines. Lines are not limited in length,
+// since serialized access grants can be long.
+func cliConfigLines(data []byte) []string {
+	lines := strings.Split(string(data), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	return lines
+}
+
+// unquote removes matching quotes around s.
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
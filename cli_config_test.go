@@ -0,0 +1,98 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/uplink"
+)
+
+func TestCLIConfig(t *testing.T) {
+	mainAccess, otherAccess := mustSerialize(t, newTestAccess(t)), mustSerialize(t, newTestAccess(t))
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "access.json"), []byte(`{
+		"default": "main",
+		"accesses": {"main": "`+mainAccess+`", "other": "`+otherAccess+`"}
+	}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.ini"), []byte(`
+# uplink configuration
+[client]
+user-agent = "backup/1.0"
+dial-timeout = 15s
+
+[metrics]
+addr = collectora.storj.io:9000
+`), 0600))
+
+	cfg, err := readCLIConfig(dir)
+	require.NoError(t, err)
+
+	access, err := cfg.access("")
+	require.NoError(t, err)
+	require.Equal(t, mainAccess, access)
+
+	access, err = cfg.access("other")
+	require.NoError(t, err)
+	require.Equal(t, otherAccess, access)
+
+	access, err = cfg.access(otherAccess)
+	require.NoError(t, err)
+	require.Equal(t, otherAccess, access)
+
+	_, err = cfg.access("missing")
+	require.True(t, ErrInvalidArg.Has(err))
+
+	config, err := cfg.apply(uplink.Config{UserAgent: "app", DialTimeout: time.Second})
+	require.NoError(t, err)
+	require.Equal(t, "backup/1.0", config.UserAgent)
+	require.Equal(t, 15*time.Second, config.DialTimeout)
+	require.Equal(t, "collectora.storj.io:9000", cfg.Settings["metrics.addr"])
+}
+
+func TestLegacyCLIConfig(t *testing.T) {
+	mainAccess := mustSerialize(t, newTestAccess(t))
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(`# legacy configuration
+access: main
+accesses:
+    main: `+mainAccess+`
+client.dial-timeout: 20s
+`), 0600))
+
+	cfg, err := readCLIConfig(dir)
+	require.NoError(t, err)
+
+	access, err := cfg.access("")
+	require.NoError(t, err)
+	require.Equal(t, mainAccess, access)
+
+	config, err := cfg.apply(uplink.Config{UserAgent: "app"})
+	require.NoError(t, err)
+	require.Equal(t, "app", config.UserAgent)
+	require.Equal(t, 20*time.Second, config.DialTimeout)
+
+	_, err = readCLIConfig(t.TempDir())
+	require.True(t, ErrInvalidArg.Has(err))
+}
+
+func TestCLIConfigLongLines(t *testing.T) {
+	long := strings.Repeat("a", 128*1024)
+
+	values, err := parseCLIConfigINI([]byte("[accesses]\r\nlong = " + long + "\r\n"))
+	require.NoError(t, err)
+	require.Equal(t, long, values["accesses.long"])
+
+	values, err = parseLegacyCLIConfig([]byte("accesses:\n    long: " + long))
+	require.NoError(t, err)
+	require.Equal(t, long, values["accesses.long"])
+}
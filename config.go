@@ -36,7 +36,9 @@ func uplink_config_request_access_with_passphrase(config C.UplinkConfig, satelli
 
 	cfg := uplinkConfig(config)
 
+	span := startSpan(&ctx, nil, "uplink_config_request_access_with_passphrase", "", "")
 	access, err := cfg.RequestAccessWithPassphrase(ctx, C.GoString(satellite_address), C.GoString(api_key), C.GoString(passphrase))
+	span.finish(err)
 	if err != nil {
 		return C.UplinkAccessResult{
 			error: mallocError(err),
@@ -44,7 +46,7 @@ func uplink_config_request_access_with_passphrase(config C.UplinkConfig, satelli
 	}
 
 	return C.UplinkAccessResult{
-		access: (*C.UplinkAccess)(mallocHandle(universe.Add(&Access{access}))),
+		access: (*C.UplinkAccess)(mallocHandle(universe.Add(&Access{Access: access}))),
 	}
 }
 
@@ -68,7 +70,11 @@ func uplink_config_open_project(config C.UplinkConfig, access *C.UplinkAccess) C
 	scope := rootScope(C.GoString(config.temp_directory))
 
 	cfg := uplinkConfig(config)
-	proj, err := cfg.OpenProject(scope.ctx, acc.Access)
+	logDebug("opening project", "satellite", acc.SatelliteAddress(), "user_agent", cfg.UserAgent)
+	ctx := scope.ctx
+	span := startSpan(&ctx, nil, "uplink_config_open_project", "", "")
+	proj, err := cfg.OpenProject(ctx, acc.Access)
+	span.finish(err)
 	if err != nil {
 		scope.cancel()
 		return C.UplinkProjectResult{
@@ -77,10 +83,18 @@ func uplink_config_open_project(config C.UplinkConfig, access *C.UplinkAccess) C
 	}
 
 	return C.UplinkProjectResult{
-		project: (*C.UplinkProject)(mallocHandle(universe.Add(&Project{scope, proj}))),
+		project: (*C.UplinkProject)(mallocHandle(universe.Add(&Project{scope: scope, Project: proj, stats: &projectStats{}}))),
 	}
 }
 
+// uplink_config_request_project_salt requests the salt of the project of
+// access from the satellite using config.
+//
+//export uplink_config_request_project_salt
+func uplink_config_request_project_salt(config C.UplinkConfig, access *C.UplinkAccess) C.UplinkSaltResult { //nolint:golint
+	return requestProjectSalt(uplinkConfig(config), access, "uplink_config_request_project_salt")
+}
+
 func uplinkConfig(config C.UplinkConfig) uplink.Config {
 	return uplink.Config{
 		UserAgent:   C.GoString(config.user_agent),
@@ -48,10 +48,14 @@ func uplink_copy_object(project *C.UplinkProject, old_bucket_name, old_object_ke
 		}
 	}
 
-	object, err := proj.CopyObject(proj.scope.ctx,
+	ctx := proj.scope.ctx
+	span := proj.startSpan(&ctx, "uplink_copy_object", C.GoString(old_bucket_name), C.GoString(old_object_key))
+	object, err := proj.CopyObject(ctx,
 		C.GoString(old_bucket_name), C.GoString(old_object_key),
 		C.GoString(new_bucket_name), C.GoString(new_object_key),
 		nil)
+	span.finish(err)
+	proj.stats.record(C.UPLINK_OPERATION_COPY_OBJECT, err)
 	return C.UplinkObjectResult{
 		error:  mallocError(err),
 		object: mallocObject(object),
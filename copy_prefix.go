@@ -0,0 +1,270 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+import (
+	"context"
+	"strings"
+	"unsafe"
+
+	"storj.io/uplink"
+)
+
+// prefixReport is the outcome of copying or moving a prefix.
+type prefixReport struct {
+	objectsListed    int64
+	objectsCompleted int64
+	bytesCompleted   int64
+	objectsFailed    int64
+	// cursor is the key relative to the old prefix up to which all objects
+	// were handled.
+	cursor   string
+	failures []prefixFailure
+}
+
+// prefixFailure is an object that failed to be copied or moved.
+type prefixFailure struct {
+	key string
+	err error
+}
+
+// prefixTransfer describes copying or moving the objects of a prefix.
+type prefixTransfer struct {
+	oldBucket, oldPrefix string
+	newBucket, newPrefix string
+	concurrency          int
+	cursor               string
+}
+
+// uplink_copy_prefix copies all objects under old_prefix in old_bucket_name
+// to new_prefix in new_bucket_name.
+//
+// The objects are listed recursively and copied in batches with at most
+// options.concurrency copies in parallel. The batch in which an object fails
+// is finished, after which the copying stops. The report counts the listed,
+// copied and failed objects and has a cursor to resume copying from.
+//
+// old_prefix, new_prefix: must end with "/", or be empty for the whole
+// bucket. new_prefix must not be under old_prefix in the same bucket.
+//
+//export uplink_copy_prefix
+func uplink_copy_prefix(project *C.UplinkProject, old_bucket_name, old_prefix, new_bucket_name, new_prefix *C.uplink_const_char,
+	options *C.UplinkCopyPrefixOptions) C.UplinkPrefixReportResult {
+	var concurrency C.int32_t
+	var cursor *C.uplink_const_char
+	if options != nil {
+		concurrency, cursor = options.concurrency, options.cursor
+	}
+
+	return transferPrefix(project, "uplink_copy_prefix", old_bucket_name, old_prefix, new_bucket_name, new_prefix, concurrency, cursor,
+		func(ctx context.Context, proj *Project, oldBucket, oldKey, newBucket, newKey string) error {
+			_, err := proj.CopyObject(ctx, oldBucket, oldKey, newBucket, newKey, nil)
+			proj.stats.record(C.UPLINK_OPERATION_COPY_OBJECT, err)
+			return err
+		})
+}
+
+// uplink_free_prefix_report_result frees the resources associated with prefix report result.
+//
+//export uplink_free_prefix_report_result
+func uplink_free_prefix_report_result(result C.UplinkPrefixReportResult) {
+	uplink_free_error(result.error)
+	if result.report == nil {
+		return
+	}
+	defer free(unsafe.Pointer(result.report))
+
+	free(unsafe.Pointer(result.report.cursor))
+	if result.report.failures == nil {
+		return
+	}
+	defer free(unsafe.Pointer(result.report.failures))
+
+	for _, failure := range unsafe.Slice(result.report.failures, int(result.report.failures_count)) {
+		free(unsafe.Pointer(failure.key))
+		uplink_free_error(failure.error)
+	}
+}
+
+// transferPrefix validates the arguments of uplink_copy_prefix and
+// uplink_move_prefix and calls transfer for every object under old_prefix.
+func transferPrefix(
+	project *C.UplinkProject,
+	name string,
+	old_bucket_name, old_prefix, new_bucket_name, new_prefix *C.uplink_const_char,
+	concurrency C.int32_t,
+	cursor *C.uplink_const_char,
+	transfer func(ctx context.Context, proj *Project, oldBucket, oldKey, newBucket, newKey string) error,
+) C.UplinkPrefixReportResult {
+	if project == nil {
+		return C.UplinkPrefixReportResult{
+			error: mallocError(ErrNull.New("project")),
+		}
+	}
+	if old_bucket_name == nil {
+		return C.UplinkPrefixReportResult{
+			error: mallocError(ErrNull.New("old_bucket_name")),
+		}
+	}
+	if old_prefix == nil {
+		return C.UplinkPrefixReportResult{
+			error: mallocError(ErrNull.New("old_prefix")),
+		}
+	}
+	if new_bucket_name == nil {
+		return C.UplinkPrefixReportResult{
+			error: mallocError(ErrNull.New("new_bucket_name")),
+		}
+	}
+	if new_prefix == nil {
+		return C.UplinkPrefixReportResult{
+			error: mallocError(ErrNull.New("new_prefix")),
+		}
+	}
+
+	proj, ok := universe.Get(project._handle).(*Project)
+	if !ok {
+		return C.UplinkPrefixReportResult{
+			error: mallocError(ErrInvalidHandle.New("project")),
+		}
+	}
+
+	opts := prefixTransfer{
+		oldBucket:   C.GoString(old_bucket_name),
+		oldPrefix:   C.GoString(old_prefix),
+		newBucket:   C.GoString(new_bucket_name),
+		newPrefix:   C.GoString(new_prefix),
+		concurrency: limitConcurrency(int(concurrency)),
+	}
+	if cursor != nil {
+		opts.cursor = C.GoString(cursor)
+	}
+	if err := opts.validate(); err != nil {
+		return C.UplinkPrefixReportResult{
+			error: mallocError(err),
+		}
+	}
+
+	scope := proj.scope.child()
+	defer scope.cancel()
+
+	span := proj.startSpan(&scope.ctx, name, opts.oldBucket, opts.oldPrefix)
+	iterator := proj.ListObjects(scope.ctx, opts.oldBucket, &uplink.ListObjectsOptions{
+		Prefix:    opts.oldPrefix,
+		Cursor:    opts.cursor,
+		Recursive: true,
+		System:    true,
+	})
+	report, err := opts.run(scope.ctx, iterator, func(ctx context.Context, oldKey, newKey string) error {
+		return transfer(ctx, proj, opts.oldBucket, oldKey, opts.newBucket, newKey)
+	})
+	proj.stats.record(C.UPLINK_OPERATION_LIST_OBJECTS, iterator.Err())
+	span.finish(err)
+
+	return C.UplinkPrefixReportResult{
+		report: mallocPrefixReport(report),
+		error:  mallocError(err),
+	}
+}
+
+// mallocPrefixReport converts the report to C.
+func mallocPrefixReport(report prefixReport) *C.UplinkPrefixReport {
+	creport := (*C.UplinkPrefixReport)(calloc(1, C.sizeof_UplinkPrefixReport))
+	creport.objects_listed = C.int64_t(report.objectsListed)
+	creport.objects_completed = C.int64_t(report.objectsCompleted)
+	creport.bytes_completed = C.int64_t(report.bytesCompleted)
+	creport.objects_failed = C.int64_t(report.objectsFailed)
+	creport.cursor = cstring(report.cursor)
+
+	if len(report.failures) > 0 {
+		creport.failures = (*C.UplinkPrefixFailure)(calloc(C.size_t(len(report.failures)), C.sizeof_UplinkPrefixFailure))
+		creport.failures_count = C.size_t(len(report.failures))
+
+		array := unsafe.Slice(creport.failures, len(report.failures))
+		for i, failure := range report.failures {
+			array[i].key = cstring(failure.key)
+			array[i].error = mallocError(failure.err)
+		}
+	}
+
+	return creport
+}
+
+// validate checks the prefixes of the transfer.
+//
+// Objects copied or moved under the old prefix would be listed again, so the
+// new prefix must not be under the old prefix in the same bucket.
+func (opts *prefixTransfer) validate() error {
+	if opts.oldPrefix != "" && !strings.HasSuffix(opts.oldPrefix, "/") {
+		return ErrInvalidArg.New("old_prefix: must end with slash")
+	}
+	if opts.newPrefix != "" && !strings.HasSuffix(opts.newPrefix, "/") {
+		return ErrInvalidArg.New("new_prefix: must end with slash")
+	}
+	if opts.oldBucket == opts.newBucket && strings.HasPrefix(opts.newPrefix, opts.oldPrefix) {
+		return ErrInvalidArg.New("new_prefix: must not be under old_prefix in the same bucket")
+	}
+	return nil
+}
+
+// run copies or moves the objects from iterator in batches of listBatchSize
+// with transfer.
+//
+// It stops after the batch in which an object failed and returns the error of
+// the first failed object, or the listing error. The cursor of the report only
+// advances over objects, which were handled in listing order without a
+// failure before them.
+func (opts *prefixTransfer) run(ctx context.Context, iterator objectIterator, transfer func(ctx context.Context, oldKey, newKey string) error) (prefixReport, error) {
+	report := prefixReport{cursor: opts.cursor}
+
+	var batch []*uplink.Object
+	flush := func() error {
+		errs := parallel(ctx, len(batch), opts.concurrency, func(ctx context.Context, i int) error {
+			key := batch[i].Key
+			return transfer(ctx, key, opts.newPrefix+strings.TrimPrefix(key, opts.oldPrefix))
+		})
+
+		var firstErr error
+		for i, object := range batch {
+			if errs[i] != nil {
+				report.objectsFailed++
+				report.failures = append(report.failures, prefixFailure{key: object.Key, err: errs[i]})
+				if firstErr == nil {
+					firstErr = errs[i]
+				}
+				continue
+			}
+
+			report.objectsCompleted++
+			report.bytesCompleted += object.System.ContentLength
+			if firstErr == nil {
+				report.cursor = strings.TrimPrefix(object.Key, opts.oldPrefix)
+			}
+		}
+		batch = batch[:0]
+		return firstErr
+	}
+
+	for iterator.Next() {
+		object := iterator.Item()
+		if object.IsPrefix {
+			continue
+		}
+		report.objectsListed++
+
+		batch = append(batch, object)
+		if len(batch) >= listBatchSize {
+			if err := flush(); err != nil {
+				return report, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return report, err
+	}
+
+	return report, iterator.Err()
+}
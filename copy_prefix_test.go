@@ -0,0 +1,122 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrefixTransferValidate(t *testing.T) {
+	for _, tc := range []struct {
+		oldBucket, oldPrefix string
+		newBucket, newPrefix string
+		valid                bool
+	}{
+		{"alpha", "photos/", "alpha", "archive/photos/", true},
+		{"alpha", "photos/2020/", "alpha", "photos/", true},
+		{"alpha", "", "beta", "", true},
+		{"alpha", "photos/", "beta", "photos/", true},
+		{"alpha", "photos", "beta", "photos/", false},
+		{"alpha", "photos/", "beta", "photos", false},
+		{"alpha", "photos/", "alpha", "photos/", false},
+		{"alpha", "photos/", "alpha", "photos/old/", false},
+		{"alpha", "", "alpha", "archive/", false},
+	} {
+		opts := prefixTransfer{
+			oldBucket: tc.oldBucket, oldPrefix: tc.oldPrefix,
+			newBucket: tc.newBucket, newPrefix: tc.newPrefix,
+		}
+		err := opts.validate()
+		if tc.valid {
+			require.NoError(t, err, tc)
+		} else {
+			require.True(t, ErrInvalidArg.Has(err), tc)
+		}
+	}
+}
+
+func TestPrefixTransferRun(t *testing.T) {
+	objects := testObjects(listBatchSize + 5)
+
+	var mu sync.Mutex
+	transferred := map[string]string{}
+	transfer := func(ctx context.Context, oldKey, newKey string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		transferred[oldKey] = newKey
+		return nil
+	}
+
+	opts := prefixTransfer{oldPrefix: "tenant/", newPrefix: "archive/tenant/", concurrency: 4}
+	report, err := opts.run(context.Background(), &sliceIterator{objects: objects}, transfer)
+	require.NoError(t, err)
+	require.Equal(t, prefixReport{
+		objectsListed:    int64(len(objects)),
+		objectsCompleted: int64(len(objects)),
+		bytesCompleted:   int64(len(objects)) * 10,
+		cursor:           "1004",
+	}, report)
+	require.Len(t, transferred, len(objects))
+	require.Equal(t, "archive/tenant/0000", transferred["tenant/0000"])
+}
+
+func TestPrefixTransferRunFailure(t *testing.T) {
+	objects := testObjects(listBatchSize + 5)
+	failure := errors.New("copy failed")
+
+	var mu sync.Mutex
+	var transferred []string
+	transfer := func(ctx context.Context, oldKey, newKey string) error {
+		if oldKey == "tenant/0003" || oldKey == "tenant/0007" {
+			return failure
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		transferred = append(transferred, oldKey)
+		return nil
+	}
+
+	opts := prefixTransfer{oldPrefix: "tenant/", newPrefix: "archive/", concurrency: 4, cursor: "previous"}
+	report, err := opts.run(context.Background(), &sliceIterator{objects: objects}, transfer)
+	require.ErrorIs(t, err, failure)
+
+	// the first batch is finished, but listing stops
+	require.Equal(t, int64(listBatchSize), report.objectsListed)
+	require.Equal(t, int64(listBatchSize-2), report.objectsCompleted)
+	require.Equal(t, int64(2), report.objectsFailed)
+	require.Len(t, transferred, listBatchSize-2)
+	require.Equal(t, []prefixFailure{
+		{key: "tenant/0003", err: failure},
+		{key: "tenant/0007", err: failure},
+	}, report.failures)
+
+	// resuming starts at the first failed object
+	require.Equal(t, "0002", report.cursor)
+
+	report, err = opts.run(context.Background(), &sliceIterator{objects: objects[3:3]}, transfer)
+	require.NoError(t, err)
+	require.Equal(t, "previous", report.cursor)
+}
+
+func TestPrefixTransferRunListingError(t *testing.T) {
+	listErr := errors.New("listing failed")
+
+	var transferred []string
+	transfer := func(ctx context.Context, oldKey, newKey string) error {
+		transferred = append(transferred, oldKey)
+		return nil
+	}
+
+	opts := prefixTransfer{oldPrefix: "tenant/", newPrefix: "archive/", concurrency: 1}
+	report, err := opts.run(context.Background(), &sliceIterator{objects: testObjects(2), err: listErr}, transfer)
+	require.ErrorIs(t, err, listErr)
+	require.Equal(t, int64(2), report.objectsCompleted)
+	require.Equal(t, "0001", report.cursor)
+	require.Equal(t, []string{"tenant/0000", "tenant/0001"}, transferred)
+}
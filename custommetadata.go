@@ -41,13 +41,13 @@ func customMetadataToC(customMetadata uplink.CustomMetadata) C.UplinkCustomMetad
 	array := unsafe.Slice(entries, len(sorted))
 
 	for i, kv := range sorted {
-		ckey := C.CString(kv.key)
+		ckey := cstring(kv.key)
 
 		array[i] = C.UplinkCustomMetadataEntry{
 			key:        ckey,
 			key_length: C.size_t(len(kv.key)),
 
-			value:        C.CString(kv.value),
+			value:        cstring(kv.value),
 			value_length: C.size_t(len(kv.value)),
 		}
 	}
@@ -78,7 +78,7 @@ func freeCustomMetadataData(custom *C.UplinkCustomMetadata) {
 		return
 	}
 	defer func() {
-		C.free(unsafe.Pointer(custom.entries))
+		free(unsafe.Pointer(custom.entries))
 		custom.entries = nil
 		custom.count = 0
 	}()
@@ -87,9 +87,9 @@ func freeCustomMetadataData(custom *C.UplinkCustomMetadata) {
 
 	for i := range array {
 		e := &array[i]
-		C.free(unsafe.Pointer(e.key))
+		free(unsafe.Pointer(e.key))
 		e.key = nil
-		C.free(unsafe.Pointer(e.value))
+		free(unsafe.Pointer(e.value))
 		e.value = nil
 	}
 }
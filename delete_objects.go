@@ -0,0 +1,178 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+import (
+	"context"
+	"unsafe"
+
+	"storj.io/uplink"
+)
+
+// defaultConcurrency is the number of objects handled in parallel when the
+// options don't specify it.
+const defaultConcurrency = 10
+
+// maxConcurrency is the largest number of objects handled in parallel. Every
+// object in flight needs a goroutine and a connection, so larger requested
+// values are lowered to it.
+const maxConcurrency = listBatchSize
+
+// limitConcurrency returns requested limited to maxConcurrency, or
+// defaultConcurrency when requested is 0 or negative.
+func limitConcurrency(requested int) int {
+	switch {
+	case requested <= 0:
+		return defaultConcurrency
+	case requested > maxConcurrency:
+		return maxConcurrency
+	}
+	return requested
+}
+
+// deleteObjectsItem is the outcome of deleting a single key.
+type deleteObjectsItem struct {
+	deleted *uplink.Object
+	err     error
+}
+
+// uplink_delete_objects deletes the objects at object_keys in bucket_name.
+//
+// The objects are deleted in parallel, a failure doesn't stop the other keys
+// from being deleted. The result has an item for every key in the same order.
+// Deleting a key without an object succeeds with deleted unset.
+//
+//export uplink_delete_objects
+func uplink_delete_objects(
+	project *C.UplinkProject,
+	bucket_name *C.uplink_const_char,
+	object_keys **C.uplink_const_char,
+	object_keys_count C.size_t,
+	options *C.UplinkDeleteObjectsOptions,
+) C.UplinkDeleteObjectsResult {
+	if project == nil {
+		return C.UplinkDeleteObjectsResult{
+			error: mallocError(ErrNull.New("project")),
+		}
+	}
+	if bucket_name == nil {
+		return C.UplinkDeleteObjectsResult{
+			error: mallocError(ErrNull.New("bucket_name")),
+		}
+	}
+	if object_keys == nil && object_keys_count > 0 {
+		return C.UplinkDeleteObjectsResult{
+			error: mallocError(ErrNull.New("object_keys")),
+		}
+	}
+
+	proj, ok := universe.Get(project._handle).(*Project)
+	if !ok {
+		return C.UplinkDeleteObjectsResult{
+			error: mallocError(ErrInvalidHandle.New("project")),
+		}
+	}
+
+	concurrency := defaultConcurrency
+	skipMetadata := false
+	if options != nil {
+		concurrency = limitConcurrency(int(options.concurrency))
+		skipMetadata = bool(options.skip_metadata)
+	}
+
+	count, ok := safeConvertToInt(object_keys_count)
+	if !ok {
+		return C.UplinkDeleteObjectsResult{
+			error: mallocError(ErrInvalidArg.New("object_keys_count too large")),
+		}
+	}
+
+	keys := make([]*string, count)
+	if count > 0 {
+		for i, key := range unsafe.Slice(object_keys, count) {
+			if key != nil {
+				s := C.GoString(key)
+				keys[i] = &s
+			}
+		}
+	}
+
+	bucket := C.GoString(bucket_name)
+
+	scope := proj.scope.child()
+	defer scope.cancel()
+
+	span := proj.startSpan(&scope.ctx, "uplink_delete_objects", bucket, "")
+	items := deleteObjects(scope.ctx, func(ctx context.Context, key string) (*uplink.Object, error) {
+		return proj.deleteObject(ctx, bucket, key)
+	}, keys, concurrency)
+	span.finish(nil)
+
+	citems := (*C.UplinkDeleteObjectsItem)(calloc(C.size_t(len(items)), C.sizeof_UplinkDeleteObjectsItem))
+	array := unsafe.Slice(citems, len(items))
+	failed := 0
+	for i, item := range items {
+		if item.err != nil {
+			array[i].code = errorCode(item.err)
+			array[i].error = mallocError(item.err)
+			failed++
+			continue
+		}
+		array[i].deleted = C.bool(item.deleted != nil)
+		if !skipMetadata {
+			array[i].object = mallocObject(item.deleted)
+		}
+	}
+
+	return C.UplinkDeleteObjectsResult{
+		items:        citems,
+		items_count:  C.size_t(len(items)),
+		failed_count: C.size_t(failed),
+	}
+}
+
+// uplink_free_delete_objects_result frees the resources associated with delete objects result.
+//
+//export uplink_free_delete_objects_result
+func uplink_free_delete_objects_result(result C.UplinkDeleteObjectsResult) {
+	uplink_free_error(result.error)
+	if result.items == nil {
+		return
+	}
+	defer free(unsafe.Pointer(result.items))
+
+	for _, item := range unsafe.Slice(result.items, int(result.items_count)) {
+		uplink_free_error(item.error)
+		uplink_free_object(item.object)
+	}
+}
+
+// deleteObject deletes the object at key in bucket.
+func (proj *Project) deleteObject(ctx context.Context, bucket, key string) (*uplink.Object, error) {
+	deleted, err := proj.DeleteObject(ctx, bucket, key)
+	proj.stats.record(C.UPLINK_OPERATION_DELETE_OBJECT, err)
+	return deleted, err
+}
+
+// deleteObjects deletes every key with at most concurrency deletes in
+// parallel and returns the outcome for each of them. A nil entry is a NULL
+// key.
+func deleteObjects(ctx context.Context, del func(context.Context, string) (*uplink.Object, error), keys []*string, concurrency int) []deleteObjectsItem {
+	concurrency = limitConcurrency(concurrency)
+
+	items := make([]deleteObjectsItem, len(keys))
+	errs := parallel(ctx, len(keys), concurrency, func(ctx context.Context, i int) (err error) {
+		if keys[i] == nil {
+			return ErrNull.New("object_keys[%d]", i)
+		}
+		items[i].deleted, err = del(ctx, *keys[i])
+		return err
+	})
+	for i, err := range errs {
+		items[i].err = err
+	}
+	return items
+}
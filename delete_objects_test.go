@@ -0,0 +1,98 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/uplink"
+)
+
+func TestDeleteObjects(t *testing.T) {
+	first, second, missing := "first", "second", "missing"
+
+	var mu sync.Mutex
+	var deleted []string
+	del := func(ctx context.Context, key string) (*uplink.Object, error) {
+		switch key {
+		case second:
+			return nil, uplink.ErrObjectKeyInvalid
+		case missing:
+			return nil, nil
+		}
+		mu.Lock()
+		deleted = append(deleted, key)
+		mu.Unlock()
+		return &uplink.Object{Key: key}, nil
+	}
+
+	items := deleteObjects(context.Background(), del, []*string{&first, nil, &second, &missing}, 2)
+	require.Len(t, items, 4)
+	require.NoError(t, items[0].err)
+	require.Equal(t, first, items[0].deleted.Key)
+	require.True(t, ErrNull.Has(items[1].err))
+	require.ErrorIs(t, items[2].err, uplink.ErrObjectKeyInvalid)
+	require.NoError(t, items[3].err)
+	require.Nil(t, items[3].deleted)
+	require.Equal(t, []string{first}, deleted)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	items = deleteObjects(ctx, del, []*string{&first}, 2)
+	require.ErrorIs(t, items[0].err, context.Canceled)
+	require.Len(t, deleted, 1)
+}
+
+func TestDeleteObjectsConcurrency(t *testing.T) {
+	keys := make([]*string, 20)
+	for i := range keys {
+		key := string(rune('a' + i))
+		keys[i] = &key
+	}
+
+	var running, peak int32
+	del := func(ctx context.Context, key string) (*uplink.Object, error) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if n <= old || atomic.CompareAndSwapInt32(&peak, old, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		if key == "c" {
+			return nil, errors.New("delete failed")
+		}
+		return &uplink.Object{Key: key}, nil
+	}
+
+	items := deleteObjects(context.Background(), del, keys, 3)
+	require.Len(t, items, len(keys))
+	require.LessOrEqual(t, atomic.LoadInt32(&peak), int32(3))
+	for i, item := range items {
+		if *keys[i] == "c" {
+			require.EqualError(t, item.err, "delete failed")
+			continue
+		}
+		require.NoError(t, item.err)
+		require.Equal(t, *keys[i], item.deleted.Key)
+	}
+}
+
+func TestLimitConcurrency(t *testing.T) {
+	require.Equal(t, defaultConcurrency, limitConcurrency(-1))
+	require.Equal(t, defaultConcurrency, limitConcurrency(0))
+	require.Equal(t, 3, limitConcurrency(3))
+	require.Equal(t, maxConcurrency, limitConcurrency(maxConcurrency))
+	require.Equal(t, maxConcurrency, limitConcurrency(math.MaxInt32))
+}
@@ -0,0 +1,217 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+import (
+	"context"
+	"strings"
+	"unsafe"
+
+	"storj.io/uplink"
+)
+
+// listBatchSize is the number of listed objects processed before listing
+// continues.
+const listBatchSize = 1000
+
+// objectIterator iterates over objects, it's implemented by
+// *uplink.ObjectIterator.
+type objectIterator interface {
+	Next() bool
+	Item() *uplink.Object
+	Err() error
+}
+
+// deletePrefixProgress counts the objects handled by deletePrefix.
+type deletePrefixProgress struct {
+	objectsListed  int64
+	bytesListed    int64
+	objectsDeleted int64
+	bytesDeleted   int64
+	objectsFailed  int64
+}
+
+// deletePrefixOptions configures deletePrefix.
+type deletePrefixOptions struct {
+	concurrency int
+	dryRun      bool
+	// progress is called for every object after it's handled, err is nil
+	// when it was deleted or only listed.
+	progress func(progress deletePrefixProgress, key string, err error)
+}
+
+// uplink_delete_prefix deletes all objects under prefix in bucket_name.
+//
+// The objects are listed recursively and deleted in batches with at most
+// options.concurrency deletes in parallel. A failure doesn't stop the other
+// objects from being deleted. The result counts the listed, deleted and
+// failed objects; its error is the listing error or the error of the first
+// object that failed to delete.
+//
+// prefix: must end with "/", an empty prefix deletes all objects in the
+// bucket, but keeps the bucket.
+//
+//export uplink_delete_prefix
+func uplink_delete_prefix(
+	project *C.UplinkProject,
+	bucket_name *C.uplink_const_char,
+	prefix *C.uplink_const_char,
+	options *C.UplinkDeletePrefixOptions,
+) C.UplinkDeletePrefixResult {
+	if project == nil {
+		return C.UplinkDeletePrefixResult{
+			error: mallocError(ErrNull.New("project")),
+		}
+	}
+	if bucket_name == nil {
+		return C.UplinkDeletePrefixResult{
+			error: mallocError(ErrNull.New("bucket_name")),
+		}
+	}
+	if prefix == nil {
+		return C.UplinkDeletePrefixResult{
+			error: mallocError(ErrNull.New("prefix")),
+		}
+	}
+
+	proj, ok := universe.Get(project._handle).(*Project)
+	if !ok {
+		return C.UplinkDeletePrefixResult{
+			error: mallocError(ErrInvalidHandle.New("project")),
+		}
+	}
+
+	goprefix := C.GoString(prefix)
+	if goprefix != "" && !strings.HasSuffix(goprefix, "/") {
+		return C.UplinkDeletePrefixResult{
+			error: mallocError(ErrInvalidArg.New("prefix: must end with slash")),
+		}
+	}
+
+	opts := deletePrefixOptions{concurrency: defaultConcurrency}
+	if options != nil {
+		opts.concurrency = limitConcurrency(int(options.concurrency))
+		opts.dryRun = bool(options.dry_run)
+		if options.progress != nil {
+			callback, userData := options.progress, options.user_data
+			opts.progress = func(progress deletePrefixProgress, key string, err error) {
+				cprogress := (*C.UplinkDeletePrefixProgress)(calloc(1, C.sizeof_UplinkDeletePrefixProgress))
+				defer free(unsafe.Pointer(cprogress))
+				*cprogress = deletePrefixProgressToC(progress)
+
+				ckey := cstring(key)
+				defer free(unsafe.Pointer(ckey))
+
+				var code C.int32_t
+				if err != nil {
+					code = errorCode(err)
+				}
+				callDeletePrefixCallback(callback, cprogress, ckey, code, userData)
+			}
+		}
+	}
+
+	bucket := C.GoString(bucket_name)
+
+	scope := proj.scope.child()
+	defer scope.cancel()
+
+	span := proj.startSpan(&scope.ctx, "uplink_delete_prefix", bucket, goprefix)
+	iterator := proj.ListObjects(scope.ctx, bucket, &uplink.ListObjectsOptions{
+		Prefix:    goprefix,
+		Recursive: true,
+		System:    true,
+	})
+	progress, err := deletePrefix(scope.ctx, iterator, func(ctx context.Context, key string) (*uplink.Object, error) {
+		return proj.deleteObject(ctx, bucket, key)
+	}, opts)
+	proj.stats.record(C.UPLINK_OPERATION_LIST_OBJECTS, iterator.Err())
+	span.finish(err)
+
+	return C.UplinkDeletePrefixResult{
+		progress: deletePrefixProgressToC(progress),
+		error:    mallocError(err),
+	}
+}
+
+// uplink_free_delete_prefix_result frees the resources associated with delete prefix result.
+//
+//export uplink_free_delete_prefix_result
+func uplink_free_delete_prefix_result(result C.UplinkDeletePrefixResult) {
+	uplink_free_error(result.error)
+}
+
+// deletePrefixProgressToC converts the progress to C.
+func deletePrefixProgressToC(progress deletePrefixProgress) C.UplinkDeletePrefixProgress {
+	return C.UplinkDeletePrefixProgress{
+		objects_listed:  C.int64_t(progress.objectsListed),
+		bytes_listed:    C.int64_t(progress.bytesListed),
+		objects_deleted: C.int64_t(progress.objectsDeleted),
+		bytes_deleted:   C.int64_t(progress.bytesDeleted),
+		objects_failed:  C.int64_t(progress.objectsFailed),
+	}
+}
+
+// deletePrefix deletes the objects from iterator in batches of
+// listBatchSize.
+//
+// It returns the listing error, or the error of the first object that failed
+// to delete.
+func deletePrefix(ctx context.Context, iterator objectIterator, del func(context.Context, string) (*uplink.Object, error), opts deletePrefixOptions) (progress deletePrefixProgress, firstErr error) {
+	var batch []*uplink.Object
+	flush := func() {
+		keys := make([]*string, len(batch))
+		for i, object := range batch {
+			keys[i] = &object.Key
+		}
+
+		var items []deleteObjectsItem
+		if !opts.dryRun {
+			items = deleteObjects(ctx, del, keys, opts.concurrency)
+		}
+
+		for i, object := range batch {
+			var err error
+			if !opts.dryRun {
+				err = items[i].err
+				if err != nil {
+					progress.objectsFailed++
+					if firstErr == nil {
+						firstErr = err
+					}
+				} else {
+					progress.objectsDeleted++
+					progress.bytesDeleted += object.System.ContentLength
+				}
+			}
+			if opts.progress != nil {
+				opts.progress(progress, object.Key, err)
+			}
+		}
+		batch = batch[:0]
+	}
+
+	for iterator.Next() {
+		object := iterator.Item()
+		if object.IsPrefix {
+			continue
+		}
+		progress.objectsListed++
+		progress.bytesListed += object.System.ContentLength
+
+		batch = append(batch, object)
+		if len(batch) >= listBatchSize {
+			flush()
+		}
+	}
+	// objects listed before a listing failure are still deleted
+	flush()
+
+	if err := iterator.Err(); err != nil {
+		return progress, err
+	}
+	return progress, firstErr
+}
@@ -0,0 +1,127 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/uplink"
+)
+
+// sliceIterator iterates over objects and fails with err at the end.
+type sliceIterator struct {
+	objects []*uplink.Object
+	current *uplink.Object
+	err     error
+}
+
+func (it *sliceIterator) Next() bool {
+	if len(it.objects) == 0 {
+		return false
+	}
+	it.current, it.objects = it.objects[0], it.objects[1:]
+	return true
+}
+
+func (it *sliceIterator) Item() *uplink.Object { return it.current }
+
+func (it *sliceIterator) Err() error { return it.err }
+
+func testObjects(count int) []*uplink.Object {
+	objects := make([]*uplink.Object, count)
+	for i := range objects {
+		objects[i] = &uplink.Object{
+			Key:    fmt.Sprintf("tenant/%04d", i),
+			System: uplink.SystemMetadata{ContentLength: 10},
+		}
+	}
+	return objects
+}
+
+func TestDeletePrefix(t *testing.T) {
+	objects := testObjects(listBatchSize + 5)
+
+	var mu sync.Mutex
+	deleted := map[string]bool{}
+	del := func(ctx context.Context, key string) (*uplink.Object, error) {
+		if key == "tenant/0003" {
+			return nil, uplink.ErrPermissionDenied
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		deleted[key] = true
+		return &uplink.Object{Key: key}, nil
+	}
+
+	var keys []string
+	var last deletePrefixProgress
+	progress, err := deletePrefix(context.Background(), &sliceIterator{objects: objects}, del, deletePrefixOptions{
+		concurrency: 4,
+		progress: func(progress deletePrefixProgress, key string, err error) {
+			keys = append(keys, key)
+			if key == "tenant/0003" {
+				require.ErrorIs(t, err, uplink.ErrPermissionDenied)
+			} else {
+				require.NoError(t, err)
+			}
+			last = progress
+		},
+	})
+	require.ErrorIs(t, err, uplink.ErrPermissionDenied)
+	require.Equal(t, deletePrefixProgress{
+		objectsListed:  int64(len(objects)),
+		bytesListed:    int64(len(objects)) * 10,
+		objectsDeleted: int64(len(objects)) - 1,
+		bytesDeleted:   (int64(len(objects)) - 1) * 10,
+		objectsFailed:  1,
+	}, progress)
+	require.Equal(t, progress, last)
+	require.Len(t, keys, len(objects))
+	require.Len(t, deleted, len(objects)-1)
+	for i, object := range objects {
+		require.Equal(t, object.Key, keys[i])
+	}
+}
+
+func TestDeletePrefixDryRun(t *testing.T) {
+	objects := testObjects(3)
+
+	del := func(ctx context.Context, key string) (*uplink.Object, error) {
+		t.Fatal("dry run must not delete")
+		return nil, nil
+	}
+
+	calls := 0
+	progress, err := deletePrefix(context.Background(), &sliceIterator{objects: objects}, del, deletePrefixOptions{
+		dryRun: true,
+		progress: func(progress deletePrefixProgress, key string, err error) {
+			require.NoError(t, err)
+			calls++
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, deletePrefixProgress{objectsListed: 3, bytesListed: 30}, progress)
+	require.Equal(t, 3, calls)
+}
+
+func TestDeletePrefixListingError(t *testing.T) {
+	listErr := errors.New("listing failed")
+
+	var deleted []string
+	del := func(ctx context.Context, key string) (*uplink.Object, error) {
+		deleted = append(deleted, key)
+		return &uplink.Object{Key: key}, nil
+	}
+
+	progress, err := deletePrefix(context.Background(), &sliceIterator{objects: testObjects(1), err: listErr}, del, deletePrefixOptions{})
+	require.ErrorIs(t, err, listErr)
+	require.Equal(t, int64(1), progress.objectsDeleted)
+	require.Equal(t, []string{"tenant/0000"}, deleted)
+}
@@ -15,6 +15,12 @@ import (
 type Download struct {
 	scope
 	download *uplink.Download
+
+	// closed is set when the download was closed by the caller.
+	closed bool
+
+	stats    *projectStats
+	progress inProgress
 }
 
 // uplink_download_object starts  download to the specified key.
@@ -54,15 +60,22 @@ func uplink_download_object(project *C.UplinkProject, bucket_name, object_key *C
 		opts.Length = int64(options.length)
 	}
 
+	logDebug("starting download", "bucket", C.GoString(bucket_name), "key", C.GoString(object_key))
+	span := proj.startSpan(&scope.ctx, "uplink_download_object", C.GoString(bucket_name), C.GoString(object_key))
 	download, err := proj.DownloadObject(scope.ctx, C.GoString(bucket_name), C.GoString(object_key), opts)
+	span.finish(err)
+	proj.stats.record(C.UPLINK_OPERATION_DOWNLOAD_OBJECT, err)
 	if err != nil {
 		return C.UplinkDownloadResult{
 			error: mallocError(err),
 		}
 	}
 
+	down := &Download{scope: scope, download: download, stats: proj.stats}
+	down.progress.start(&proj.stats.downloads)
+
 	return C.UplinkDownloadResult{
-		download: (*C.UplinkDownload)(mallocHandle(universe.Add(&Download{scope, download}))),
+		download: (*C.UplinkDownload)(mallocHandle(universe.Add(down))),
 	}
 }
 
@@ -99,7 +112,13 @@ func uplink_download_read(download *C.UplinkDownload, bytes unsafe.Pointer, leng
 	}
 
 	buf := unsafe.Slice((*byte)(bytes), ilength)
+	ctx := down.scope.ctx
+	span := startSpan(&ctx, nil, "uplink_download_read", "", "")
 	n, err := down.download.Read(buf)
+	span.addBytes(n)
+	span.finish(err)
+	down.stats.bytesDownloaded.Add(int64(n))
+	down.stats.recordError(err)
 	return C.UplinkReadResult{
 		bytes_read: C.size_t(n),
 		error:      mallocError(err),
@@ -149,7 +168,15 @@ func uplink_close_download(download *C.UplinkDownload) *C.UplinkError {
 		return mallocError(ErrInvalidHandle.New("download"))
 	}
 
-	return mallocError(down.download.Close())
+	down.closed = true
+	down.progress.finish()
+
+	ctx := down.scope.ctx
+	span := startSpan(&ctx, nil, "uplink_close_download", "", "")
+	err := down.download.Close()
+	span.finish(err)
+	down.stats.recordError(err)
+	return mallocError(err)
 }
 
 // uplink_free_download_result frees any associated resources.
@@ -165,7 +192,7 @@ func freeDownload(download *C.UplinkDownload) {
 	if download == nil {
 		return
 	}
-	defer C.free(unsafe.Pointer(download))
+	defer free(unsafe.Pointer(download))
 	defer universe.Del(download._handle)
 
 	down, ok := universe.Get(download._handle).(*Download)
@@ -173,8 +200,12 @@ func freeDownload(download *C.UplinkDownload) {
 		return
 	}
 
+	down.progress.finish()
 	down.cancel()
 	// in case we haven't already closed the download
-	_ = down.download.Close()
-	// TODO: log error when we didn't close manually and the close returns an error
+	if !down.closed {
+		if err := down.download.Close(); err != nil {
+			logError("closing download on free failed", "error", err)
+		}
+	}
 }
@@ -8,14 +8,25 @@ package main
 import "C"
 import (
 	"context"
+	"time"
 	"unsafe"
 
+	"storj.io/uplink"
 	"storj.io/uplink/edge"
+	privateEdge "storj.io/uplink/private/edge"
 )
 
 // edge_register_access gets credentials for the Storj-hosted Gateway-mt and linkshare service.
 // All files uploaded under the Access are then accessible via those services.
 //
+// The registered access grant is derived from access and returned serialized
+// in the result. Revoking it with uplink_revoke_serialized_access revokes the
+// credentials without revoking access. With options.expires the registered
+// access grant and the credentials expire.
+//
+// The auth service has no way to list or delete credentials, so the caller
+// has to keep the registered access grant for revoking them.
+//
 //export edge_register_access
 func edge_register_access(
 	config C.EdgeConfig,
@@ -28,11 +39,7 @@ func edge_register_access(
 		}
 	}
 
-	goConfig := edge.Config{
-		AuthServiceAddress:            C.GoString(config.auth_service_address),
-		CertificatePEM:                []byte(C.GoString(config.certificate_pem)),
-		InsecureUnencryptedConnection: bool(config.insecure_unencrypted_connection),
-	}
+	goConfig := edgeConfig(config)
 	if options == nil {
 		options = &C.EdgeRegisterAccessOptions{}
 	}
@@ -40,6 +47,16 @@ func edge_register_access(
 		Public: bool(options.is_public),
 	}
 
+	var expires time.Time
+	if options.expires != 0 {
+		expires = time.Unix(int64(options.expires), 0)
+		if !expires.After(time.Now()) {
+			return C.EdgeCredentialsResult{
+				error: mallocError(ErrInvalidArg.New("expires: must be in the future")),
+			}
+		}
+	}
+
 	goAccess, ok := universe.Get(access._handle).(*Access)
 	if !ok {
 		return C.EdgeCredentialsResult{
@@ -48,22 +65,58 @@ func edge_register_access(
 	}
 
 	ctx := context.Background()
-	goCredentials, err := goConfig.RegisterAccess(
-		ctx,
-		goAccess.Access,
-		&goOptions,
-	)
+	span := startSpan(&ctx, nil, "edge_register_access", "", "")
+	registration, err := registerEdgeAccess(ctx, &goConfig, goAccess.Access, &goOptions, expires)
+	span.finish(err)
+	if err != nil {
+		return C.EdgeCredentialsResult{
+			error: mallocError(err),
+		}
+	}
+
+	registered, err := registration.access.Serialize()
+	if err != nil {
+		return C.EdgeCredentialsResult{
+			error: mallocError(err),
+		}
+	}
 
 	return C.EdgeCredentialsResult{
-		error:       mallocError(err),
-		credentials: mallocEdgeCredentials(goCredentials),
+		credentials: mallocEdgeCredentials(registration),
+		access:      cstring(registered),
+	}
+}
+
+// edgeConfig converts config to Go.
+func edgeConfig(config C.EdgeConfig) edge.Config {
+	return edge.Config{
+		AuthServiceAddress:            C.GoString(config.auth_service_address),
+		CertificatePEM:                []byte(C.GoString(config.certificate_pem)),
+		InsecureUnencryptedConnection: bool(config.insecure_unencrypted_connection),
+	}
+}
+
+// registerEdgeAccess registers an access grant derived from access with the
+// auth service, restricted to expires when it's set.
+func registerEdgeAccess(ctx context.Context, config *edge.Config, access *uplink.Access, options *edge.RegisterAccessOptions, expires time.Time) (*edgeRegistration, error) {
+	registered, err := edgeRegistrationAccess(access, expires)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials, err := privateEdge.RegisterAccess(ctx, config, registered, options)
+	if err != nil {
+		return nil, err
 	}
+
+	return newEdgeRegistration(registered, credentials, options.Public)
 }
 
 //export edge_free_credentials_result
 func edge_free_credentials_result(result C.EdgeCredentialsResult) {
 	uplink_free_error(result.error)
 	edge_free_credentials(result.credentials)
+	free(unsafe.Pointer(result.access))
 }
 
 //export edge_free_credentials
@@ -72,29 +125,29 @@ func edge_free_credentials(credentials *C.EdgeCredentials) {
 		return
 	}
 
-	defer C.free(unsafe.Pointer(credentials))
+	defer free(unsafe.Pointer(credentials))
 
-	if credentials.access_key_id != nil {
-		C.free(unsafe.Pointer(credentials.access_key_id))
-	}
-	if credentials.secret_key != nil {
-		C.free(unsafe.Pointer(credentials.secret_key))
-	}
-	if credentials.endpoint != nil {
-		C.free(unsafe.Pointer(credentials.endpoint))
-	}
+	freeEdgeCredentialsFields(credentials)
 }
 
-func mallocEdgeCredentials(credentials *edge.Credentials) *C.EdgeCredentials {
-	if credentials == nil {
+func mallocEdgeCredentials(registration *edgeRegistration) *C.EdgeCredentials {
+	if registration == nil {
 		return nil
 	}
 
 	cCredentials := (*C.EdgeCredentials)(calloc(1, C.sizeof_EdgeCredentials))
-	*cCredentials = C.EdgeCredentials{
-		access_key_id: C.CString(credentials.AccessKeyID),
-		secret_key:    C.CString(credentials.SecretKey),
-		endpoint:      C.CString(credentials.Endpoint),
-	}
+	*cCredentials = edgeCredentials(registration)
 	return cCredentials
 }
+
+// edgeCredentials converts the credentials of registration to C, the strings
+// are allocated.
+func edgeCredentials(registration *edgeRegistration) C.EdgeCredentials {
+	return C.EdgeCredentials{
+		access_key_id: cstring(registration.credentials.AccessKeyID),
+		secret_key:    cstring(registration.credentials.SecretKey),
+		endpoint:      cstring(registration.credentials.Endpoint),
+		expires:       C.int64_t(registration.expiresUnix()),
+		is_public:     C.bool(registration.public),
+	}
+}
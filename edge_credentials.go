@@ -0,0 +1,92 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+import (
+	"time"
+	"unsafe"
+
+	"storj.io/common/grant"
+	"storj.io/common/macaroon"
+	"storj.io/uplink"
+	"storj.io/uplink/edge"
+	privateEdge "storj.io/uplink/private/edge"
+)
+
+// edgeRegistration is an access grant registered with the auth service.
+type edgeRegistration struct {
+	access      *uplink.Access
+	credentials edge.Credentials
+	public      bool
+	expires     *time.Time
+}
+
+// freeEdgeCredentialsFields frees the strings of credentials.
+func freeEdgeCredentialsFields(credentials *C.EdgeCredentials) {
+	free(unsafe.Pointer(credentials.access_key_id))
+	free(unsafe.Pointer(credentials.secret_key))
+	free(unsafe.Pointer(credentials.endpoint))
+}
+
+// edgeRegistrationAccess returns the access grant to register for access.
+//
+// It's derived from access with a caveat that only has a nonce and the expiry,
+// when expires is set. Revoking it revokes the credentials without revoking
+// access, and no permission of access is lost.
+func edgeRegistrationAccess(access *uplink.Access, expires time.Time) (*uplink.Access, error) {
+	g, err := toGrant(access)
+	if err != nil {
+		return nil, err
+	}
+
+	caveat := macaroon.Caveat{}
+	if !expires.IsZero() {
+		caveat.NotAfter = &expires
+	}
+
+	apiKey, err := g.APIKey.Restrict(macaroon.WithNonce(caveat))
+	if err != nil {
+		return nil, err
+	}
+
+	return fromGrant(&grant.Access{
+		SatelliteAddress: g.SatelliteAddress,
+		APIKey:           apiKey,
+		EncAccess:        g.EncAccess,
+	})
+}
+
+// newEdgeRegistration returns the registration of access with credentials.
+func newEdgeRegistration(access *uplink.Access, credentials *privateEdge.Credentials, public bool) (*edgeRegistration, error) {
+	g, err := toGrant(access)
+	if err != nil {
+		return nil, err
+	}
+	caveats, err := apiKeyCaveats(g.APIKey)
+	if err != nil {
+		return nil, err
+	}
+
+	_, expires, _ := caveatLimits(caveats)
+	if limited := credentials.FreeTierRestrictedExpiration; limited != nil && (expires == nil || limited.Before(*expires)) {
+		expires = limited
+	}
+
+	return &edgeRegistration{
+		access:      access,
+		credentials: credentials.Credentials,
+		public:      public,
+		expires:     expires,
+	}, nil
+}
+
+// expiresUnix returns the expiry in Unix seconds, 0 when it doesn't expire.
+func (registration *edgeRegistration) expiresUnix() int64 {
+	if registration.expires == nil {
+		return 0
+	}
+	return registration.expires.Unix()
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/uplink/edge"
+	privateEdge "storj.io/uplink/private/edge"
+)
+
+func TestEdgeRegistrationAccess(t *testing.T) {
+	access := newTestAccess(t)
+	expires := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	// without expiry only a nonce is added
+	registered, err := edgeRegistrationAccess(access, time.Time{})
+	require.NoError(t, err)
+	require.NotEqual(t, mustSerialize(t, access), mustSerialize(t, registered))
+
+	g, err := toGrant(registered)
+	require.NoError(t, err)
+	caveats, err := apiKeyCaveats(g.APIKey)
+	require.NoError(t, err)
+	require.Len(t, caveats, 1)
+	require.Empty(t, caveats[0].AllowedPaths)
+	require.NotEmpty(t, caveats[0].Nonce)
+	for _, action := range subsetActions {
+		require.True(t, actionAllowed(caveats, action), action)
+	}
+	_, notAfter, _ := caveatLimits(caveats)
+	require.Nil(t, notAfter)
+
+	// the registered access is different every time, so that it can be
+	// revoked on its own
+	other, err := edgeRegistrationAccess(access, time.Time{})
+	require.NoError(t, err)
+	require.NotEqual(t, mustSerialize(t, registered), mustSerialize(t, other))
+
+	// with expiry only the expiry is restricted
+	registered, err = edgeRegistrationAccess(access, expires)
+	require.NoError(t, err)
+
+	g, err = toGrant(registered)
+	require.NoError(t, err)
+	caveats, err = apiKeyCaveats(g.APIKey)
+	require.NoError(t, err)
+	require.Len(t, caveats, 1)
+	require.Empty(t, caveats[0].AllowedPaths)
+
+	for _, action := range subsetActions {
+		require.True(t, actionAllowed(caveats, action), action)
+	}
+
+	_, notAfter, _ = caveatLimits(caveats)
+	require.NotNil(t, notAfter)
+	require.True(t, expires.Equal(*notAfter))
+}
+
+func TestEdgeRegistration(t *testing.T) {
+	expires := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	registration, err := newEdgeRegistration(newTestAccess(t), &privateEdge.Credentials{
+		Credentials: edge.Credentials{AccessKeyID: "unlimited"},
+	}, false)
+	require.NoError(t, err)
+	require.Zero(t, registration.expiresUnix())
+
+	registered, err := edgeRegistrationAccess(newTestAccess(t), expires)
+	require.NoError(t, err)
+
+	limited, err := newEdgeRegistration(registered, &privateEdge.Credentials{
+		Credentials: edge.Credentials{AccessKeyID: "limited"},
+	}, true)
+	require.NoError(t, err)
+	require.Equal(t, expires.Unix(), limited.expiresUnix())
+	require.True(t, limited.public)
+
+	freeTier := expires.Add(-time.Minute)
+	restricted, err := newEdgeRegistration(registered, &privateEdge.Credentials{
+		Credentials:                  edge.Credentials{AccessKeyID: "restricted"},
+		FreeTierRestrictedExpiration: &freeTier,
+	}, true)
+	require.NoError(t, err)
+	require.Equal(t, freeTier.Unix(), restricted.expiresUnix())
+}
@@ -0,0 +1,115 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+//go:build uplink_testing
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+import (
+	"sync"
+
+	"storj.io/uplink-c/internal/edgeauth"
+)
+
+// testAuthServices are the running auth service stand-ins by address.
+var testAuthServices struct {
+	mu      sync.Mutex
+	servers map[string]*edgeauth.Server
+}
+
+// edge_internal_StartAuthService starts an in-process stand-in for the edge
+// auth service for testing and returns its address. It accepts unencrypted
+// connections, so EdgeConfig.insecure_unencrypted_connection must be set.
+// Registered credentials have endpoint as the gateway endpoint.
+//
+//export edge_internal_StartAuthService
+func edge_internal_StartAuthService(endpoint *C.uplink_const_char) C.UplinkStringResult {
+	if endpoint == nil {
+		return C.UplinkStringResult{
+			error: mallocError(ErrNull.New("endpoint")),
+		}
+	}
+
+	server, err := edgeauth.Start(C.GoString(endpoint))
+	if err != nil {
+		return C.UplinkStringResult{
+			error: mallocError(err),
+		}
+	}
+
+	testAuthServices.mu.Lock()
+	if testAuthServices.servers == nil {
+		testAuthServices.servers = map[string]*edgeauth.Server{}
+	}
+	testAuthServices.servers[server.Addr()] = server
+	testAuthServices.mu.Unlock()
+
+	return C.UplinkStringResult{
+		string: cstring(server.Addr()),
+	}
+}
+
+// edge_internal_AuthServiceAccess returns the access grant registered for
+// access_key_id with the auth service stand-in at address.
+//
+//export edge_internal_AuthServiceAccess
+func edge_internal_AuthServiceAccess(address, access_key_id *C.uplink_const_char) C.UplinkStringResult {
+	if address == nil {
+		return C.UplinkStringResult{
+			error: mallocError(ErrNull.New("address")),
+		}
+	}
+	if access_key_id == nil {
+		return C.UplinkStringResult{
+			error: mallocError(ErrNull.New("access_key_id")),
+		}
+	}
+
+	server, ok := testAuthService(C.GoString(address))
+	if !ok {
+		return C.UplinkStringResult{
+			error: mallocError(ErrInvalidArg.New("no auth service at %q", C.GoString(address))),
+		}
+	}
+
+	registration, ok := server.Registration(C.GoString(access_key_id))
+	if !ok {
+		return C.UplinkStringResult{
+			error: mallocError(ErrInvalidArg.New("access key ID %q is not registered", C.GoString(access_key_id))),
+		}
+	}
+
+	return C.UplinkStringResult{
+		string: cstring(registration.AccessGrant),
+	}
+}
+
+// edge_internal_StopAuthService stops the auth service stand-in at address.
+//
+//export edge_internal_StopAuthService
+func edge_internal_StopAuthService(address *C.uplink_const_char) *C.UplinkError {
+	if address == nil {
+		return mallocError(ErrNull.New("address"))
+	}
+
+	testAuthServices.mu.Lock()
+	server, ok := testAuthServices.servers[C.GoString(address)]
+	delete(testAuthServices.servers, C.GoString(address))
+	testAuthServices.mu.Unlock()
+	if !ok {
+		return mallocError(ErrInvalidArg.New("no auth service at %q", C.GoString(address)))
+	}
+
+	return mallocError(server.Close())
+}
+
+// testAuthService returns the auth service stand-in at address.
+func testAuthService(address string) (*edgeauth.Server, bool) {
+	testAuthServices.mu.Lock()
+	defer testAuthServices.mu.Unlock()
+
+	server, ok := testAuthServices.servers[address]
+	return server, ok
+}
@@ -0,0 +1,322 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+
+	"storj.io/common/grant"
+	"storj.io/uplink"
+	"storj.io/uplink/edge"
+)
+
+// Formats of the share manifest.
+const (
+	shareManifestJSON = C.EDGE_SHARE_MANIFEST_JSON
+	shareManifestCSV  = C.EDGE_SHARE_MANIFEST_CSV
+)
+
+// shareManifest is the list of publicly shared objects.
+type shareManifest struct {
+	AccessKeyID string                `json:"access_key_id"`
+	Bucket      string                `json:"bucket"`
+	Prefix      string                `json:"prefix"`
+	Expires     *int64                `json:"expires"`
+	Objects     []shareManifestObject `json:"objects"`
+}
+
+// shareManifestObject is a shared object in the manifest.
+type shareManifestObject struct {
+	Key            string            `json:"key"`
+	URL            string            `json:"url"`
+	Size           int64             `json:"size"`
+	Created        int64             `json:"created"`
+	Expires        *int64            `json:"expires"`
+	CustomMetadata map[string]string `json:"custom_metadata"`
+}
+
+// edge_share_manifest registers a public access for the objects in bucket
+// under prefix and returns a manifest with the raw linkshare URL, size and
+// metadata of every object.
+//
+// The objects are listed with project, which must be opened with access or
+// an access with the same encryption keys. The registered access grant only
+// allows downloading and listing the prefix, it's returned so that it can be
+// revoked with uplink_revoke_serialized_access. Objects with a key ending in
+// "/" have no raw linkshare URL and are not in the manifest.
+//
+// base_url: linkshare service, e.g. https://link.us1.storjshare.io
+// prefix: optional prefix, which must end with "/".
+//
+//export edge_share_manifest
+func edge_share_manifest(
+	config C.EdgeConfig,
+	project *C.UplinkProject,
+	access *C.UplinkAccess,
+	base_url *C.uplink_const_char,
+	bucket_name *C.uplink_const_char,
+	prefix *C.uplink_const_char,
+	options *C.EdgeShareManifestOptions,
+) C.EdgeShareManifestResult {
+	if project == nil {
+		return C.EdgeShareManifestResult{
+			error: mallocError(ErrNull.New("project")),
+		}
+	}
+	if access == nil {
+		return C.EdgeShareManifestResult{
+			error: mallocError(ErrNull.New("access")),
+		}
+	}
+	if base_url == nil {
+		return C.EdgeShareManifestResult{
+			error: mallocError(ErrNull.New("base_url")),
+		}
+	}
+	if bucket_name == nil {
+		return C.EdgeShareManifestResult{
+			error: mallocError(ErrNull.New("bucket_name")),
+		}
+	}
+
+	proj, ok := universe.Get(project._handle).(*Project)
+	if !ok {
+		return C.EdgeShareManifestResult{
+			error: mallocError(ErrInvalidHandle.New("project")),
+		}
+	}
+	acc, ok := universe.Get(access._handle).(*Access)
+	if !ok {
+		return C.EdgeShareManifestResult{
+			error: mallocError(ErrInvalidHandle.New("access")),
+		}
+	}
+
+	if options == nil {
+		options = &C.EdgeShareManifestOptions{}
+	}
+	format := int(options.format)
+	if format != shareManifestJSON && format != shareManifestCSV {
+		return C.EdgeShareManifestResult{
+			error: mallocError(ErrInvalidArg.New("format: unknown format %d", format)),
+		}
+	}
+
+	var expires time.Time
+	if options.expires != 0 {
+		expires = time.Unix(int64(options.expires), 0)
+		if !expires.After(time.Now()) {
+			return C.EdgeShareManifestResult{
+				error: mallocError(ErrInvalidArg.New("expires: must be in the future")),
+			}
+		}
+	}
+
+	bucket := C.GoString(bucket_name)
+	var goprefix string
+	if prefix != nil {
+		goprefix = C.GoString(prefix)
+	}
+	if goprefix != "" && !strings.HasSuffix(goprefix, "/") {
+		return C.EdgeShareManifestResult{
+			error: mallocError(ErrInvalidArg.New("prefix: must end with slash")),
+		}
+	}
+
+	gobaseURL := C.GoString(base_url)
+	if _, err := url.ParseRequestURI(gobaseURL); err != nil {
+		return C.EdgeShareManifestResult{
+			error: mallocError(ErrInvalidArg.New("base_url: invalid url %q", gobaseURL)),
+		}
+	}
+
+	scope := proj.scope.child()
+	defer scope.cancel()
+
+	span := proj.startSpan(&scope.ctx, "edge_share_manifest", bucket, goprefix)
+	manifest, registration, err := shareManifestFor(scope.ctx, proj, edgeConfig(config), acc, gobaseURL, bucket, goprefix, expires)
+	span.finish(err)
+	if err != nil {
+		return C.EdgeShareManifestResult{
+			error: mallocError(err),
+		}
+	}
+
+	data, err := manifest.encode(format)
+	if err != nil {
+		return C.EdgeShareManifestResult{
+			error: mallocError(err),
+		}
+	}
+
+	registered, err := registration.access.Serialize()
+	if err != nil {
+		return C.EdgeShareManifestResult{
+			error: mallocError(err),
+		}
+	}
+
+	return C.EdgeShareManifestResult{
+		credentials: mallocEdgeCredentials(registration),
+		access:      cstring(registered),
+		manifest:    cstring(string(data)),
+	}
+}
+
+// edge_free_share_manifest_result frees the resources associated with share manifest result.
+//
+//export edge_free_share_manifest_result
+func edge_free_share_manifest_result(result C.EdgeShareManifestResult) {
+	uplink_free_error(result.error)
+	edge_free_credentials(result.credentials)
+	free(unsafe.Pointer(result.access))
+	free(unsafe.Pointer(result.manifest))
+}
+
+// shareManifestFor lists the objects under prefix, registers a public access
+// for them and returns their manifest.
+//
+// The objects are listed first, so that nothing is registered when listing
+// fails.
+func shareManifestFor(ctx context.Context, proj *Project, config edge.Config, acc *Access, baseURL, bucket, prefix string, expires time.Time) (*shareManifest, *edgeRegistration, error) {
+	iterator := proj.ListObjects(ctx, bucket, &uplink.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: true,
+		System:    true,
+		Custom:    true,
+	})
+
+	var objects []*uplink.Object
+	for iterator.Next() {
+		object := iterator.Item()
+		if object.IsPrefix || strings.HasSuffix(object.Key, "/") {
+			continue
+		}
+		objects = append(objects, object)
+	}
+	err := iterator.Err()
+	proj.stats.record(C.UPLINK_OPERATION_LIST_OBJECTS, err)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the registered access can only download and list the objects
+	shared, err := shareAccess(acc.Access, grant.Permission{
+		AllowDownload: true,
+		AllowList:     true,
+	}, []grant.SharePrefix{{Bucket: bucket, Prefix: prefix}})
+	if err != nil {
+		return nil, nil, err
+	}
+	derived, err := acc.derive(shared)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	registration, err := registerEdgeAccess(ctx, &config, derived.Access, &edge.RegisterAccessOptions{Public: true}, expires)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	manifest, err := newShareManifest(baseURL, registration, bucket, prefix, objects)
+	if err != nil {
+		return nil, nil, err
+	}
+	return manifest, registration, nil
+}
+
+// newShareManifest returns the manifest of objects shared with registration.
+func newShareManifest(baseURL string, registration *edgeRegistration, bucket, prefix string, objects []*uplink.Object) (*shareManifest, error) {
+	manifest := &shareManifest{
+		AccessKeyID: registration.credentials.AccessKeyID,
+		Bucket:      bucket,
+		Prefix:      prefix,
+		Expires:     unixOrNil(registration.expires),
+		Objects:     []shareManifestObject{},
+	}
+
+	for _, object := range objects {
+		link, err := edge.JoinShareURL(baseURL, manifest.AccessKeyID, bucket, object.Key, &edge.ShareURLOptions{Raw: true})
+		if err != nil {
+			return nil, err
+		}
+
+		var expires *time.Time
+		if !object.System.Expires.IsZero() {
+			expires = &object.System.Expires
+		}
+		custom := map[string]string(object.Custom)
+		if custom == nil {
+			custom = map[string]string{}
+		}
+
+		manifest.Objects = append(manifest.Objects, shareManifestObject{
+			Key:            object.Key,
+			URL:            link,
+			Size:           object.System.ContentLength,
+			Created:        object.System.Created.Unix(),
+			Expires:        unixOrNil(expires),
+			CustomMetadata: custom,
+		})
+	}
+	return manifest, nil
+}
+
+// encode encodes the manifest in format.
+//
+// CSV has a header row and a row for every object. The custom metadata
+// column is JSON encoded and the expires column is empty when not set.
+func (manifest *shareManifest) encode(format int) ([]byte, error) {
+	if format == shareManifestJSON {
+		return json.Marshal(manifest)
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write([]string{"key", "url", "size", "created", "expires", "custom_metadata"}); err != nil {
+		return nil, err
+	}
+	for _, object := range manifest.Objects {
+		custom, err := json.Marshal(object.CustomMetadata)
+		if err != nil {
+			return nil, err
+		}
+		var expires string
+		if object.Expires != nil {
+			expires = strconv.FormatInt(*object.Expires, 10)
+		}
+		err = w.Write([]string{
+			object.Key,
+			object.URL,
+			strconv.FormatInt(object.Size, 10),
+			strconv.FormatInt(object.Created, 10),
+			expires,
+			string(custom),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// unixOrNil returns t in Unix seconds, nil when t is nil.
+func unixOrNil(t *time.Time) *int64 {
+	if t == nil {
+		return nil
+	}
+	unix := t.Unix()
+	return &unix
+}
@@ -0,0 +1,71 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/uplink"
+	"storj.io/uplink/edge"
+)
+
+func TestShareManifest(t *testing.T) {
+	created := time.Unix(1700000000, 0)
+	expires := time.Unix(2000000000, 0)
+
+	registration := &edgeRegistration{
+		credentials: edge.Credentials{AccessKeyID: "l5pucy3dmvzxgs3fpfewix27l5pq"},
+		expires:     &expires,
+	}
+	objects := []*uplink.Object{
+		{
+			Key:    "datasets/a.csv",
+			System: uplink.SystemMetadata{Created: created, ContentLength: 42},
+			Custom: uplink.CustomMetadata{"content-type": "text/csv"},
+		},
+		{
+			Key:    "datasets/b c.bin",
+			System: uplink.SystemMetadata{Created: created, Expires: expires, ContentLength: 7},
+		},
+	}
+
+	manifest, err := newShareManifest("https://link.storjshare.io", registration, "public", "datasets/", objects)
+	require.NoError(t, err)
+	require.Len(t, manifest.Objects, 2)
+	require.Equal(t, "https://link.storjshare.io/raw/l5pucy3dmvzxgs3fpfewix27l5pq/public/datasets/a.csv", manifest.Objects[0].URL)
+
+	parsed, err := parseShareURL(manifest.Objects[1].URL)
+	require.NoError(t, err)
+	require.Equal(t, "datasets/b c.bin", parsed.key)
+
+	data, err := manifest.encode(shareManifestJSON)
+	require.NoError(t, err)
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, "l5pucy3dmvzxgs3fpfewix27l5pq", decoded["access_key_id"])
+	require.EqualValues(t, 2000000000, decoded["expires"])
+	first := decoded["objects"].([]interface{})[0].(map[string]interface{})
+	require.EqualValues(t, 42, first["size"])
+	require.EqualValues(t, 1700000000, first["created"])
+	require.Nil(t, first["expires"])
+	require.Equal(t, map[string]interface{}{"content-type": "text/csv"}, first["custom_metadata"])
+
+	data, err = manifest.encode(shareManifestCSV)
+	require.NoError(t, err)
+
+	records, err := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		{"key", "url", "size", "created", "expires", "custom_metadata"},
+		{"datasets/a.csv", manifest.Objects[0].URL, "42", "1700000000", "", `{"content-type":"text/csv"}`},
+		{"datasets/b c.bin", manifest.Objects[1].URL, "7", "1700000000", "2000000000", `{}`},
+	}, records)
+}
@@ -0,0 +1,288 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+)
+
+const (
+	// presignRegion is the region used in the signature, the gateway
+	// accepts any region.
+	presignRegion = "us-east-1"
+	// presignMaxExpires is the longest validity S3 allows for presigned URLs.
+	presignMaxExpires = 7 * 24 * time.Hour
+)
+
+// edge_presign_url creates an S3 presigned URL for the object with
+// Signature Version 4. The URL is computed locally, the gateway is not
+// contacted.
+//
+// credentials: as returned from edge_register_access.
+// method: HTTP method that can be used with the URL, one of GET, PUT, HEAD and DELETE.
+// bucket: bucket of the object.
+// key: object key.
+// expires: seconds the URL is valid for, at most 604800 (7 days).
+// headers: optional headers to sign, which need to be sent with the same values when using the URL.
+//
+//export edge_presign_url
+func edge_presign_url(
+	credentials *C.EdgeCredentials,
+	method *C.uplink_const_char,
+	bucket *C.uplink_const_char,
+	key *C.uplink_const_char,
+	expires C.int64_t,
+	headers *C.EdgeHeader,
+	headers_count C.size_t,
+) C.UplinkStringResult {
+	if credentials == nil {
+		return C.UplinkStringResult{
+			error: mallocError(ErrNull.New("credentials")),
+		}
+	}
+	if method == nil {
+		return C.UplinkStringResult{
+			error: mallocError(ErrNull.New("method")),
+		}
+	}
+	if bucket == nil {
+		return C.UplinkStringResult{
+			error: mallocError(ErrNull.New("bucket")),
+		}
+	}
+	if key == nil {
+		return C.UplinkStringResult{
+			error: mallocError(ErrNull.New("key")),
+		}
+	}
+	if headers == nil && headers_count > 0 {
+		return C.UplinkStringResult{
+			error: mallocError(ErrNull.New("headers")),
+		}
+	}
+
+	gomethod := C.GoString(method)
+	switch gomethod {
+	case "GET", "PUT", "HEAD", "DELETE":
+	default:
+		return C.UplinkStringResult{
+			error: mallocError(ErrInvalidArg.New("method: %q is not supported", gomethod)),
+		}
+	}
+
+	gobucket := C.GoString(bucket)
+	if gobucket == "" {
+		return C.UplinkStringResult{
+			error: mallocError(ErrInvalidArg.New("bucket: must not be empty")),
+		}
+	}
+
+	// the range is checked before converting, so that the duration can't overflow
+	if expires <= 0 || int64(expires) > int64(presignMaxExpires/time.Second) {
+		return C.UplinkStringResult{
+			error: mallocError(ErrInvalidArg.New("expires: must be between 1 and %d seconds", int64(presignMaxExpires/time.Second))),
+		}
+	}
+	goexpires := time.Duration(expires) * time.Second
+
+	endpoint, err := url.Parse(C.GoString(credentials.endpoint))
+	if err != nil || endpoint.Host == "" {
+		return C.UplinkStringResult{
+			error: mallocError(ErrInvalidArg.New("credentials: invalid endpoint %q", C.GoString(credentials.endpoint))),
+		}
+	}
+
+	count, ok := safeConvertToInt(headers_count)
+	if !ok {
+		return C.UplinkStringResult{
+			error: mallocError(ErrInvalidArg.New("headers_count too large")),
+		}
+	}
+
+	var goheaders []presignHeader
+	if count > 0 {
+		for i, header := range unsafe.Slice(headers, count) {
+			if header.name == nil || header.value == nil {
+				return C.UplinkStringResult{
+					error: mallocError(ErrNull.New("headers[%d]", i)),
+				}
+			}
+			goheaders = append(goheaders, presignHeader{
+				name:  C.GoString(header.name),
+				value: C.GoString(header.value),
+			})
+		}
+	}
+
+	signed, err := presignURL(presignRequest{
+		endpoint:    endpoint,
+		accessKeyID: C.GoString(credentials.access_key_id),
+		secretKey:   C.GoString(credentials.secret_key),
+		method:      gomethod,
+		path:        "/" + gobucket + "/" + C.GoString(key),
+		expires:     goexpires,
+		headers:     goheaders,
+		now:         time.Now(),
+	})
+	if err != nil {
+		return C.UplinkStringResult{
+			error: mallocError(err),
+		}
+	}
+
+	return C.UplinkStringResult{
+		string: cstring(signed),
+	}
+}
+
+// presignHeader is a header included in the signature.
+type presignHeader struct {
+	name, value string
+}
+
+// presignRequest is the request to create a presigned URL for.
+type presignRequest struct {
+	endpoint    *url.URL
+	accessKeyID string
+	secretKey   string
+	method      string
+	// path is the unencoded path appended to the path of endpoint.
+	path    string
+	expires time.Duration
+	headers []presignHeader
+	now     time.Time
+}
+
+// presignURL signs the request with AWS Signature Version 4 using query
+// parameters.
+func presignURL(req presignRequest) (string, error) {
+	if req.accessKeyID == "" || req.secretKey == "" {
+		return "", ErrInvalidArg.New("credentials: access key ID and secret key are required")
+	}
+
+	now := req.now.UTC()
+	date := now.Format("20060102")
+	amzDate := now.Format("20060102T150405Z")
+	scope := date + "/" + presignRegion + "/s3/aws4_request"
+
+	headers := map[string]string{
+		"host": req.endpoint.Host,
+	}
+	for _, header := range req.headers {
+		name := strings.ToLower(strings.TrimSpace(header.name))
+		if name == "" {
+			return "", ErrInvalidArg.New("headers: name must not be empty")
+		}
+		headers[name] = strings.Join(strings.Fields(header.value), " ")
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	signedHeaders := strings.Join(names, ";")
+
+	query := map[string]string{
+		"X-Amz-Algorithm":     "AWS4-HMAC-SHA256",
+		"X-Amz-Credential":    req.accessKeyID + "/" + scope,
+		"X-Amz-Date":          amzDate,
+		"X-Amz-Expires":       strconv.FormatInt(int64(req.expires/time.Second), 10),
+		"X-Amz-SignedHeaders": signedHeaders,
+	}
+	canonicalQuery := presignQuery(query)
+
+	path := strings.TrimSuffix(req.endpoint.Path, "/") + req.path
+	canonicalPath := presignEscape(path, false)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.method,
+		canonicalPath,
+		canonicalQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		// presigned URLs don't sign the payload
+		"UNSIGNED-PAYLOAD",
+	}, "\n")
+
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		presignHash(canonicalRequest),
+	}, "\n")
+
+	signingKey := presignHMAC([]byte("AWS4"+req.secretKey), date)
+	signingKey = presignHMAC(signingKey, presignRegion)
+	signingKey = presignHMAC(signingKey, "s3")
+	signingKey = presignHMAC(signingKey, "aws4_request")
+	signature := hex.EncodeToString(presignHMAC(signingKey, stringToSign))
+
+	return req.endpoint.Scheme + "://" + req.endpoint.Host + canonicalPath + "?" +
+		canonicalQuery + "&X-Amz-Signature=" + signature, nil
+}
+
+// presignQuery returns the canonical query string of query.
+func presignQuery(query map[string]string) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, presignEscape(key, true)+"="+presignEscape(query[key], true))
+	}
+	return strings.Join(parts, "&")
+}
+
+// presignEscape percent-encodes s as required by Signature Version 4. A slash
+// is only encoded when encodeSlash is set.
+func presignEscape(s string, encodeSlash bool) string {
+	const hexDigits = "0123456789ABCDEF"
+
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '.', c == '_', c == '~',
+			c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			b.WriteByte('%')
+			b.WriteByte(hexDigits[c>>4])
+			b.WriteByte(hexDigits[c&15])
+		}
+	}
+	return b.String()
+}
+
+// presignHash returns the hex encoded SHA-256 of s.
+func presignHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// presignHMAC returns the HMAC-SHA256 of data with key.
+func presignHMAC(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	_, _ = mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -5,7 +5,13 @@ package main
 
 // #include "uplink_definitions.h"
 import "C"
-import "storj.io/uplink/edge"
+import (
+	"net/url"
+	"strings"
+	"unsafe"
+
+	"storj.io/uplink/edge"
+)
 
 // edge_join_share_url concats a linkshare URL
 // Example result: https://link.us1.storjshare.io/s/l5pucy3dmvzxgs3fpfewix27l5pq/mybucket/myprefix/myobject
@@ -43,6 +49,118 @@ func edge_join_share_url(
 
 	return C.UplinkStringResult{
 		error:  mallocError(err),
-		string: C.CString(url),
+		string: cstring(url),
+	}
+}
+
+// edge_parse_share_url splits a linkshare URL created by edge_join_share_url
+// into its parts. The existence or accessibility of the object is not checked.
+//
+// is_prefix is set when the URL shares a prefix, a bucket or the entire
+// project, i.e. when key is empty or ends with "/".
+//
+//export edge_parse_share_url
+func edge_parse_share_url(shareURL *C.uplink_const_char) C.EdgeShareURLResult {
+	if shareURL == nil {
+		return C.EdgeShareURLResult{
+			error: mallocError(ErrNull.New("shareURL")),
+		}
+	}
+
+	parsed, err := parseShareURL(C.GoString(shareURL))
+	if err != nil {
+		return C.EdgeShareURLResult{
+			error: mallocError(err),
+		}
+	}
+
+	cshare := (*C.EdgeShareURL)(calloc(1, C.sizeof_EdgeShareURL))
+	*cshare = C.EdgeShareURL{
+		base_url:      cstring(parsed.baseURL),
+		access_key_id: cstring(parsed.accessKeyID),
+		bucket:        cstring(parsed.bucket),
+		key:           cstring(parsed.key),
+		raw:           C.bool(parsed.raw),
+		is_prefix:     C.bool(parsed.isPrefix()),
+	}
+
+	return C.EdgeShareURLResult{
+		share_url: cshare,
+	}
+}
+
+// edge_free_share_url_result frees the resources associated with share URL result.
+//
+//export edge_free_share_url_result
+func edge_free_share_url_result(result C.EdgeShareURLResult) {
+	uplink_free_error(result.error)
+	if result.share_url == nil {
+		return
+	}
+	defer free(unsafe.Pointer(result.share_url))
+
+	free(unsafe.Pointer(result.share_url.base_url))
+	free(unsafe.Pointer(result.share_url.access_key_id))
+	free(unsafe.Pointer(result.share_url.bucket))
+	free(unsafe.Pointer(result.share_url.key))
+}
+
+// shareURLParts contains the parts of a linkshare URL.
+type shareURLParts struct {
+	baseURL     string
+	accessKeyID string
+	bucket      string
+	key         string
+	raw         bool
+}
+
+// isPrefix returns whether the URL shares more than a single object.
+func (share shareURLParts) isPrefix() bool {
+	return share.key == "" || strings.HasSuffix(share.key, "/")
+}
+
+// parseShareURL does the reverse of edge.JoinShareURL.
+func parseShareURL(rawURL string) (shareURLParts, error) {
+	u, err := url.ParseRequestURI(rawURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return shareURLParts{}, ErrInvalidArg.New("invalid share url: %q", rawURL)
+	}
+
+	// the base URL can have a path of its own, so the first "s" or "raw"
+	// segment starts the shared part
+	segments := strings.Split(strings.TrimPrefix(u.Path, "/"), "/")
+	for i, segment := range segments {
+		if segment != "s" && segment != "raw" {
+			continue
+		}
+
+		share := shareURLParts{
+			raw: segment == "raw",
+		}
+
+		base := url.URL{Scheme: u.Scheme, User: u.User, Host: u.Host}
+		if i > 0 {
+			base.Path = "/" + strings.Join(segments[:i], "/")
+		}
+		share.baseURL = base.String()
+
+		rest := segments[i+1:]
+		if len(rest) == 0 || rest[0] == "" {
+			return shareURLParts{}, ErrInvalidArg.New("share url has no access key ID: %q", rawURL)
+		}
+		share.accessKeyID = rest[0]
+		if len(rest) > 1 {
+			share.bucket = rest[1]
+		}
+		if len(rest) > 2 {
+			share.key = strings.Join(rest[2:], "/")
+		}
+
+		if share.raw && share.isPrefix() {
+			return shareURLParts{}, ErrInvalidArg.New("raw share url does not point to an object: %q", rawURL)
+		}
+		return share, nil
 	}
+
+	return shareURLParts{}, ErrInvalidArg.New("not a share url: %q", rawURL)
 }
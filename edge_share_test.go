@@ -0,0 +1,51 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/uplink/edge"
+)
+
+func TestParseShareURL(t *testing.T) {
+	for _, tc := range []struct {
+		baseURL, bucket, key string
+		raw, isPrefix        bool
+	}{
+		{baseURL: "https://link.storjshare.io", isPrefix: true},
+		{baseURL: "https://link.storjshare.io", bucket: "mybucket", isPrefix: true},
+		{baseURL: "https://link.storjshare.io", bucket: "mybucket", key: "photos/", isPrefix: true},
+		{baseURL: "https://link.storjshare.io", bucket: "mybucket", key: "photos/a b?.jpg"},
+		{baseURL: "https://link.storjshare.io", bucket: "mybucket", key: "photos/a.jpg", raw: true},
+		{baseURL: "http://localhost:20020/linkshare", bucket: "mybucket", key: "s/raw/a.jpg"},
+	} {
+		joined, err := edge.JoinShareURL(tc.baseURL, "l5pucy3dmvzxgs3fpfewix27l5pq", tc.bucket, tc.key, &edge.ShareURLOptions{Raw: tc.raw})
+		require.NoError(t, err)
+
+		parsed, err := parseShareURL(joined)
+		require.NoError(t, err, joined)
+		require.Equal(t, shareURLParts{
+			baseURL:     tc.baseURL,
+			accessKeyID: "l5pucy3dmvzxgs3fpfewix27l5pq",
+			bucket:      tc.bucket,
+			key:         tc.key,
+			raw:         tc.raw,
+		}, parsed, joined)
+		require.Equal(t, tc.isPrefix, parsed.isPrefix(), joined)
+	}
+
+	for _, invalid := range []string{
+		"",
+		"link.storjshare.io/s/l5pucy3dmvzxgs3fpfewix27l5pq",
+		"https://link.storjshare.io/l5pucy3dmvzxgs3fpfewix27l5pq/mybucket",
+		"https://link.storjshare.io/s/",
+		"https://link.storjshare.io/raw/l5pucy3dmvzxgs3fpfewix27l5pq/mybucket/photos/",
+	} {
+		_, err := parseShareURL(invalid)
+		require.True(t, ErrInvalidArg.Has(err), invalid)
+	}
+}
@@ -6,16 +6,32 @@ package main
 // #include "uplink_definitions.h"
 import "C"
 import (
+	"context"
+	"crypto/subtle"
 	"unsafe"
 
+	"storj.io/common/base58"
+	"storj.io/common/encryption"
+	"storj.io/common/storj"
 	"storj.io/uplink"
+	"storj.io/uplink/private/project"
 )
 
+// encryptionKeyVersion is the version byte of serialized encryption keys.
+const encryptionKeyVersion = 0
+
 // EncryptionKey represents a key for encrypting and decrypting data.
+//
+// It keeps the raw key, because uplink.EncryptionKey does not expose it and
+// the key is needed for building access grants locally.
 type EncryptionKey struct {
-	*uplink.EncryptionKey
+	key *storj.Key
 }
 
+// passphraseKeyConcurrency is the Argon2 concurrency used by
+// uplink_request_access_with_passphrase for deriving the root key.
+const passphraseKeyConcurrency = 8
+
 // uplink_derive_encryption_key derives a salted encryption key for passphrase using the
 // salt.
 //
@@ -24,6 +40,25 @@ type EncryptionKey struct {
 //
 //export uplink_derive_encryption_key
 func uplink_derive_encryption_key(passphrase *C.uplink_const_char, salt unsafe.Pointer, length C.size_t) C.UplinkEncryptionKeyResult {
+	// this matches uplink.DeriveEncryptionKey
+	return deriveEncryptionKey(passphrase, salt, length, 1)
+}
+
+// uplink_derive_passphrase_key derives the root key for passphrase the same
+// way as uplink_request_access_with_passphrase, when salt is the project salt
+// from uplink_request_project_salt.
+//
+// Access grants created with uplink_new_access from this key can read objects
+// uploaded with an access grant from uplink_request_access_with_passphrase.
+//
+//export uplink_derive_passphrase_key
+func uplink_derive_passphrase_key(passphrase *C.uplink_const_char, salt unsafe.Pointer, length C.size_t) C.UplinkEncryptionKeyResult {
+	return deriveEncryptionKey(passphrase, salt, length, passphraseKeyConcurrency)
+}
+
+// deriveEncryptionKey derives a key for passphrase and salt with the Argon2
+// concurrency.
+func deriveEncryptionKey(passphrase *C.uplink_const_char, salt unsafe.Pointer, length C.size_t, concurrency uint8) C.UplinkEncryptionKeyResult {
 	if passphrase == nil {
 		return C.UplinkEncryptionKeyResult{
 			error: mallocError(ErrNull.New("passphrase")),
@@ -44,7 +79,7 @@ func uplink_derive_encryption_key(passphrase *C.uplink_const_char, salt unsafe.P
 	}
 
 	goSalt := unsafe.Slice((*byte)(salt), ilength)
-	encKey, err := uplink.DeriveEncryptionKey(C.GoString(passphrase), goSalt)
+	encKey, err := encryption.DeriveRootKey([]byte(C.GoString(passphrase)), goSalt, "", concurrency)
 	if err != nil {
 		return C.UplinkEncryptionKeyResult{
 			error: mallocError(err),
@@ -56,6 +91,209 @@ func uplink_derive_encryption_key(passphrase *C.uplink_const_char, salt unsafe.P
 	}
 }
 
+// uplink_request_project_salt requests the salt of the project of access from
+// the satellite.
+//
+// The salt only has to be requested once per project, after which root keys
+// can be derived with uplink_derive_passphrase_key without the satellite.
+// Use uplink_config_request_project_salt to dial the satellite with a config.
+//
+//export uplink_request_project_salt
+func uplink_request_project_salt(access *C.UplinkAccess) C.UplinkSaltResult {
+	return requestProjectSalt(uplink.Config{}, access, "uplink_request_project_salt")
+}
+
+// requestProjectSalt requests the project salt using config. name is the name
+// of the exported function for tracing.
+func requestProjectSalt(config uplink.Config, access *C.UplinkAccess, name string) C.UplinkSaltResult {
+	if access == nil {
+		return C.UplinkSaltResult{
+			error: mallocError(ErrNull.New("access")),
+		}
+	}
+
+	acc, ok := universe.Get(access._handle).(*Access)
+	if !ok {
+		return C.UplinkSaltResult{
+			error: mallocError(ErrInvalidHandle.New("access")),
+		}
+	}
+
+	ctx := context.Background()
+	span := startSpan(&ctx, nil, name, "", "")
+	info, err := project.GetProjectInfo(ctx, config, acc.Access)
+	span.finish(err)
+	if err != nil {
+		return C.UplinkSaltResult{
+			error: mallocError(err),
+		}
+	}
+
+	result := C.UplinkSaltResult{
+		salt_length: C.size_t(len(info.Salt)),
+	}
+	if len(info.Salt) > 0 {
+		result.salt = (*C.uint8_t)(calloc(C.size_t(len(info.Salt)), 1))
+		copy(unsafe.Slice((*byte)(result.salt), len(info.Salt)), info.Salt)
+	}
+	return result
+}
+
+// uplink_free_salt_result frees the resources associated with salt result.
+//
+//export uplink_free_salt_result
+func uplink_free_salt_result(result C.UplinkSaltResult) {
+	uplink_free_error(result.error)
+	free(unsafe.Pointer(result.salt))
+}
+
+// uplink_encryption_key_from_bytes creates an encryption key from length raw
+// bytes. length must be 32.
+//
+//export uplink_encryption_key_from_bytes
+func uplink_encryption_key_from_bytes(bytes unsafe.Pointer, length C.size_t) C.UplinkEncryptionKeyResult {
+	if bytes == nil {
+		return C.UplinkEncryptionKeyResult{
+			error: mallocError(ErrNull.New("bytes")),
+		}
+	}
+	if length != storj.KeySize {
+		return C.UplinkEncryptionKeyResult{
+			error: mallocError(ErrInvalidArg.New("length must be %d", storj.KeySize)),
+		}
+	}
+
+	var key storj.Key
+	copy(key[:], unsafe.Slice((*byte)(bytes), storj.KeySize))
+
+	return C.UplinkEncryptionKeyResult{
+		encryption_key: (*C.UplinkEncryptionKey)(mallocHandle(universe.Add(&EncryptionKey{&key}))),
+	}
+}
+
+// uplink_encryption_key_export copies the 32 raw bytes of the encryption key
+// into buffer. length must be at least 32.
+//
+//export uplink_encryption_key_export
+func uplink_encryption_key_export(encryptionKey *C.UplinkEncryptionKey, buffer unsafe.Pointer, length C.size_t) *C.UplinkError {
+	if encryptionKey == nil {
+		return mallocError(ErrNull.New("encryption key"))
+	}
+
+	encKey, ok := universe.Get(encryptionKey._handle).(*EncryptionKey)
+	if !ok {
+		return mallocError(ErrInvalidHandle.New("encryption key"))
+	}
+
+	if buffer == nil {
+		return mallocError(ErrNull.New("buffer"))
+	}
+	if length < storj.KeySize {
+		return mallocError(ErrInvalidArg.New("length must be at least %d", storj.KeySize))
+	}
+
+	copy(unsafe.Slice((*byte)(buffer), storj.KeySize), encKey.key[:])
+	return nil
+}
+
+// uplink_encryption_key_serialize serializes the encryption key into a string.
+//
+// The string contains the raw key and must be kept secret.
+//
+//export uplink_encryption_key_serialize
+func uplink_encryption_key_serialize(encryptionKey *C.UplinkEncryptionKey) C.UplinkStringResult {
+	if encryptionKey == nil {
+		return C.UplinkStringResult{
+			error: mallocError(ErrNull.New("encryption key")),
+		}
+	}
+
+	encKey, ok := universe.Get(encryptionKey._handle).(*EncryptionKey)
+	if !ok {
+		return C.UplinkStringResult{
+			error: mallocError(ErrInvalidHandle.New("encryption key")),
+		}
+	}
+
+	return C.UplinkStringResult{
+		string: cstring(serializeEncryptionKey(encKey.key)),
+	}
+}
+
+// uplink_parse_encryption_key parses an encryption key serialized with
+// uplink_encryption_key_serialize.
+//
+//export uplink_parse_encryption_key
+func uplink_parse_encryption_key(serialized *C.uplink_const_char) C.UplinkEncryptionKeyResult {
+	if serialized == nil {
+		return C.UplinkEncryptionKeyResult{
+			error: mallocError(ErrNull.New("serialized")),
+		}
+	}
+
+	key, err := parseEncryptionKey(C.GoString(serialized))
+	if err != nil {
+		return C.UplinkEncryptionKeyResult{
+			error: mallocError(err),
+		}
+	}
+
+	return C.UplinkEncryptionKeyResult{
+		encryption_key: (*C.UplinkEncryptionKey)(mallocHandle(universe.Add(&EncryptionKey{key}))),
+	}
+}
+
+// uplink_encryption_key_equal reports whether the two encryption keys are
+// the same. The keys are compared in constant time.
+//
+//export uplink_encryption_key_equal
+func uplink_encryption_key_equal(a, b *C.UplinkEncryptionKey) C.UplinkBoolResult {
+	if a == nil {
+		return C.UplinkBoolResult{
+			error: mallocError(ErrNull.New("a")),
+		}
+	}
+	if b == nil {
+		return C.UplinkBoolResult{
+			error: mallocError(ErrNull.New("b")),
+		}
+	}
+
+	keyA, ok := universe.Get(a._handle).(*EncryptionKey)
+	if !ok {
+		return C.UplinkBoolResult{
+			error: mallocError(ErrInvalidHandle.New("a")),
+		}
+	}
+	keyB, ok := universe.Get(b._handle).(*EncryptionKey)
+	if !ok {
+		return C.UplinkBoolResult{
+			error: mallocError(ErrInvalidHandle.New("b")),
+		}
+	}
+
+	return C.UplinkBoolResult{
+		value: C.bool(subtle.ConstantTimeCompare(keyA.key[:], keyB.key[:]) == 1),
+	}
+}
+
+// serializeEncryptionKey encodes key with base58 and a checksum.
+func serializeEncryptionKey(key *storj.Key) string {
+	return base58.CheckEncode(key[:], encryptionKeyVersion)
+}
+
+// parseEncryptionKey decodes a key encoded with serializeEncryptionKey.
+func parseEncryptionKey(serialized string) (*storj.Key, error) {
+	data, version, err := base58.CheckDecode(serialized)
+	if err != nil || version != encryptionKeyVersion || len(data) != storj.KeySize {
+		return nil, ErrInvalidArg.New("invalid encryption key format")
+	}
+
+	var key storj.Key
+	copy(key[:], data)
+	return &key, nil
+}
+
 // uplink_free_encryption_key_result frees the resources associated with encryption key.
 //
 //export uplink_free_encryption_key_result
@@ -68,6 +306,6 @@ func freeEncryptionKey(encryptionKey *C.UplinkEncryptionKey) {
 	if encryptionKey == nil {
 		return
 	}
-	defer C.free(unsafe.Pointer(encryptionKey))
+	defer free(unsafe.Pointer(encryptionKey))
 	defer universe.Del(encryptionKey._handle)
 }
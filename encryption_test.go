@@ -0,0 +1,33 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/base58"
+	"storj.io/common/testrand"
+)
+
+func TestSerializeEncryptionKey(t *testing.T) {
+	key := testrand.Key()
+
+	serialized := serializeEncryptionKey(&key)
+	parsed, err := parseEncryptionKey(serialized)
+	require.NoError(t, err)
+	require.Equal(t, key, *parsed)
+
+	for _, invalid := range []string{
+		"",
+		"invalid",
+		serialized[:len(serialized)-1],
+		base58.CheckEncode(key[:16], encryptionKeyVersion),
+		base58.CheckEncode(key[:], encryptionKeyVersion+1),
+	} {
+		_, err := parseEncryptionKey(invalid)
+		require.True(t, ErrInvalidArg.Has(err), invalid)
+	}
+}
@@ -33,53 +33,60 @@ func mallocError(err error) *C.UplinkError {
 	}
 
 	cerror := (*C.UplinkError)(calloc(1, C.sizeof_UplinkError))
+	cerror.code = errorCode(err)
+	if cerror.code == C.EOF {
+		return cerror
+	}
+
+	cerror.message = cstring(fmt.Sprintf("%+v", err))
+	logDebug("returning error", "code", int32(cerror.code), "error", err)
+	return cerror
+}
 
+// errorCode returns the UPLINK_ERROR_* code for err.
+func errorCode(err error) C.int32_t {
 	switch {
 	case errors.Is(err, io.EOF):
-		cerror.code = C.EOF
-		return cerror
+		return C.EOF
 	case errors.Is(err, context.Canceled):
-		cerror.code = C.UPLINK_ERROR_CANCELED
+		return C.UPLINK_ERROR_CANCELED
 	case ErrInvalidHandle.Has(err):
-		cerror.code = C.UPLINK_ERROR_INVALID_HANDLE
+		return C.UPLINK_ERROR_INVALID_HANDLE
 
 	case errors.Is(err, uplink.ErrTooManyRequests):
-		cerror.code = C.UPLINK_ERROR_TOO_MANY_REQUESTS
+		return C.UPLINK_ERROR_TOO_MANY_REQUESTS
 	case errors.Is(err, uplink.ErrBandwidthLimitExceeded):
-		cerror.code = C.UPLINK_ERROR_BANDWIDTH_LIMIT_EXCEEDED
+		return C.UPLINK_ERROR_BANDWIDTH_LIMIT_EXCEEDED
 	case errors.Is(err, uplink.ErrStorageLimitExceeded):
-		cerror.code = C.UPLINK_ERROR_STORAGE_LIMIT_EXCEEDED
+		return C.UPLINK_ERROR_STORAGE_LIMIT_EXCEEDED
 	case errors.Is(err, uplink.ErrSegmentsLimitExceeded):
-		cerror.code = C.UPLINK_ERROR_SEGMENTS_LIMIT_EXCEEDED
+		return C.UPLINK_ERROR_SEGMENTS_LIMIT_EXCEEDED
 	case errors.Is(err, uplink.ErrPermissionDenied):
-		cerror.code = C.UPLINK_ERROR_PERMISSION_DENIED
+		return C.UPLINK_ERROR_PERMISSION_DENIED
 
 	case errors.Is(err, uplink.ErrBucketNameInvalid):
-		cerror.code = C.UPLINK_ERROR_BUCKET_NAME_INVALID
+		return C.UPLINK_ERROR_BUCKET_NAME_INVALID
 	case errors.Is(err, uplink.ErrBucketAlreadyExists):
-		cerror.code = C.UPLINK_ERROR_BUCKET_ALREADY_EXISTS
+		return C.UPLINK_ERROR_BUCKET_ALREADY_EXISTS
 	case errors.Is(err, uplink.ErrBucketNotEmpty):
-		cerror.code = C.UPLINK_ERROR_BUCKET_NOT_EMPTY
+		return C.UPLINK_ERROR_BUCKET_NOT_EMPTY
 	case errors.Is(err, uplink.ErrBucketNotFound):
-		cerror.code = C.UPLINK_ERROR_BUCKET_NOT_FOUND
+		return C.UPLINK_ERROR_BUCKET_NOT_FOUND
 
 	case errors.Is(err, uplink.ErrObjectKeyInvalid):
-		cerror.code = C.UPLINK_ERROR_OBJECT_KEY_INVALID
+		return C.UPLINK_ERROR_OBJECT_KEY_INVALID
 	case errors.Is(err, uplink.ErrObjectNotFound):
-		cerror.code = C.UPLINK_ERROR_OBJECT_NOT_FOUND
+		return C.UPLINK_ERROR_OBJECT_NOT_FOUND
 	case errors.Is(err, uplink.ErrUploadDone):
-		cerror.code = C.UPLINK_ERROR_UPLOAD_DONE
+		return C.UPLINK_ERROR_UPLOAD_DONE
 	case errors.Is(err, edge.ErrAuthDialFailed):
-		cerror.code = C.EDGE_ERROR_AUTH_DIAL_FAILED
+		return C.EDGE_ERROR_AUTH_DIAL_FAILED
 	case errors.Is(err, edge.ErrRegisterAccessFailed):
-		cerror.code = C.EDGE_ERROR_REGISTER_ACCESS_FAILED
+		return C.EDGE_ERROR_REGISTER_ACCESS_FAILED
 
 	default:
-		cerror.code = C.UPLINK_ERROR_INTERNAL
+		return C.UPLINK_ERROR_INTERNAL
 	}
-
-	cerror.message = C.CString(fmt.Sprintf("%+v", err))
-	return cerror
 }
 
 // uplink_free_error frees error data.
@@ -89,9 +96,9 @@ func uplink_free_error(err *C.UplinkError) {
 	if err == nil {
 		return
 	}
-	defer C.free(unsafe.Pointer(err))
+	defer free(unsafe.Pointer(err))
 
 	if err.message != nil {
-		C.free(unsafe.Pointer(err.message))
+		free(unsafe.Pointer(err.message))
 	}
 }
@@ -0,0 +1,47 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"storj.io/common/grant"
+	"storj.io/common/macaroon"
+	"storj.io/uplink"
+)
+
+// toGrant converts access to a grant, which exposes the API key and the
+// encryption store of the access.
+func toGrant(access *uplink.Access) (*grant.Access, error) {
+	serialized, err := access.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	return grant.ParseAccess(serialized)
+}
+
+// fromGrant converts a grant back to an access.
+func fromGrant(g *grant.Access) (*uplink.Access, error) {
+	serialized, err := g.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	return uplink.ParseAccess(serialized)
+}
+
+// apiKeyCaveats returns the caveats of the API key in the order they were added.
+func apiKeyCaveats(apiKey *macaroon.APIKey) ([]macaroon.Caveat, error) {
+	mac, err := macaroon.ParseMacaroon(apiKey.SerializeRaw())
+	if err != nil {
+		return nil, err
+	}
+
+	var caveats []macaroon.Caveat
+	for _, data := range mac.Caveats() {
+		caveat, err := macaroon.ParseCaveat(data)
+		if err != nil {
+			return nil, err
+		}
+		caveats = append(caveats, *caveat)
+	}
+	return caveats, nil
+}
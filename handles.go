@@ -4,6 +4,7 @@
 package main
 
 import (
+	"sort"
 	"sync"
 	"unsafe"
 )
@@ -65,3 +66,22 @@ func (m *handles) Empty() bool {
 	defer m.lock.Unlock()
 	return len(m.values) == 0
 }
+
+// DelAll deletes all values and returns them, newest first.
+func (m *handles) DelAll() []interface{} {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	keys := make([]handle, 0, len(m.values))
+	for key := range m.values {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, k int) bool { return keys[i] > keys[k] })
+
+	values := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, m.values[key])
+		delete(m.values, key)
+	}
+	return values
+}
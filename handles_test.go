@@ -37,3 +37,18 @@ func TestUniverse(t *testing.T) {
 		assert.Nil(t, handles.Get(handle))
 	}
 }
+
+func TestUniverse_DelAll(t *testing.T) {
+	handles := newHandles()
+	assert.Empty(t, handles.DelAll())
+
+	first := handles.Add("first")
+	second := handles.Add("second")
+	third := handles.Add("third")
+
+	assert.Equal(t, []interface{}{"third", "second", "first"}, handles.DelAll())
+	assert.True(t, handles.Empty())
+	assert.Nil(t, handles.Get(first))
+	assert.Nil(t, handles.Get(second))
+	assert.Nil(t, handles.Get(third))
+}
@@ -0,0 +1,144 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+// Package edgeauth implements a stand-in for the edge auth service, which
+// registers access grants in memory, for testing without network access.
+package edgeauth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/grant"
+	"storj.io/common/pb"
+	"storj.io/drpc/drpcmux"
+	"storj.io/drpc/drpcserver"
+)
+
+// Error is the error class of the package.
+var Error = errs.Class("edgeauth")
+
+// Registration is a registered access grant.
+type Registration struct {
+	AccessGrant string
+	SecretKey   string
+	Public      bool
+}
+
+// Server serves the DRPC register access endpoint of the auth service over an
+// unencrypted connection.
+type Server struct {
+	endpoint string
+	listener net.Listener
+	cancel   context.CancelFunc
+	done     chan error
+
+	mu            sync.Mutex
+	registrations map[string]Registration
+}
+
+// Start starts a server listening on a random local port. Registered
+// credentials have endpoint as the gateway endpoint.
+func Start(endpoint string) (*Server, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, Error.Wrap(err)
+	}
+
+	server := &Server{
+		endpoint:      endpoint,
+		listener:      listener,
+		done:          make(chan error, 1),
+		registrations: map[string]Registration{},
+	}
+
+	mux := drpcmux.New()
+	if err := pb.DRPCRegisterEdgeAuth(mux, &endpointHandler{server: server}); err != nil {
+		_ = listener.Close()
+		return nil, Error.Wrap(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	server.cancel = cancel
+	go func() {
+		server.done <- drpcserver.New(mux).Serve(ctx, listener)
+	}()
+
+	return server, nil
+}
+
+// Addr returns the address to use as the auth service address.
+func (server *Server) Addr() string {
+	return server.listener.Addr().String()
+}
+
+// Registration returns the registration of accessKeyID.
+func (server *Server) Registration(accessKeyID string) (Registration, bool) {
+	server.mu.Lock()
+	defer server.mu.Unlock()
+
+	registration, ok := server.registrations[accessKeyID]
+	return registration, ok
+}
+
+// Close stops the server.
+func (server *Server) Close() error {
+	server.cancel()
+	return Error.Wrap(<-server.done)
+}
+
+// register stores the access grant and returns new credentials for it.
+func (server *Server) register(req *pb.EdgeRegisterAccessRequest) (*pb.EdgeRegisterAccessResponse, error) {
+	if _, err := grant.ParseAccess(req.AccessGrant); err != nil {
+		return nil, Error.New("invalid access grant: %v", err)
+	}
+
+	accessKeyID, err := randomKey(17)
+	if err != nil {
+		return nil, err
+	}
+	secretKey, err := randomKey(33)
+	if err != nil {
+		return nil, err
+	}
+
+	server.mu.Lock()
+	server.registrations[accessKeyID] = Registration{
+		AccessGrant: req.AccessGrant,
+		SecretKey:   secretKey,
+		Public:      req.Public,
+	}
+	server.mu.Unlock()
+
+	return &pb.EdgeRegisterAccessResponse{
+		AccessKeyId: accessKeyID,
+		SecretKey:   secretKey,
+		Endpoint:    server.endpoint,
+	}, nil
+}
+
+// endpointHandler implements pb.DRPCEdgeAuthServer.
+type endpointHandler struct {
+	server *Server
+}
+
+// RegisterAccess implements pb.DRPCEdgeAuthServer.
+func (handler *endpointHandler) RegisterAccess(ctx context.Context, req *pb.EdgeRegisterAccessRequest) (*pb.EdgeRegisterAccessResponse, error) {
+	return handler.server.register(req)
+}
+
+// randomKey returns n random bytes in lowercase base32 like the keys of the
+// auth service.
+func randomKey(n int) (string, error) {
+	data := make([]byte, n)
+	if _, err := rand.Read(data); err != nil {
+		return "", Error.Wrap(err)
+	}
+	return strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(data)), nil
+}
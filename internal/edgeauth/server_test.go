@@ -0,0 +1,61 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package edgeauth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"storj.io/common/grant"
+	"storj.io/common/macaroon"
+	"storj.io/common/storj"
+	"storj.io/common/testrand"
+	"storj.io/uplink"
+	"storj.io/uplink-c/internal/edgeauth"
+	"storj.io/uplink/edge"
+)
+
+func TestServer(t *testing.T) {
+	server, err := edgeauth.Start("https://gateway.example")
+	require.NoError(t, err)
+	defer func() { require.NoError(t, server.Close()) }()
+
+	apiKey, err := macaroon.NewAPIKey(testrand.BytesInt(32))
+	require.NoError(t, err)
+	serialized, err := (&grant.Access{
+		SatelliteAddress: "1111111111111111111111111111111VyS547o@127.0.0.1:7777",
+		APIKey:           apiKey,
+		EncAccess:        grant.NewEncryptionAccessWithDefaultKey(&storj.Key{}),
+	}).Serialize()
+	require.NoError(t, err)
+	access, err := uplink.ParseAccess(serialized)
+	require.NoError(t, err)
+
+	config := edge.Config{
+		AuthServiceAddress:            server.Addr(),
+		InsecureUnencryptedConnection: true,
+	}
+
+	credentials, err := config.RegisterAccess(context.Background(), access, &edge.RegisterAccessOptions{Public: true})
+	require.NoError(t, err)
+	require.Len(t, credentials.AccessKeyID, 28)
+	require.Equal(t, "https://gateway.example", credentials.Endpoint)
+
+	registration, ok := server.Registration(credentials.AccessKeyID)
+	require.True(t, ok)
+	expected, err := access.Serialize()
+	require.NoError(t, err)
+	require.Equal(t, expected, registration.AccessGrant)
+	require.Equal(t, credentials.SecretKey, registration.SecretKey)
+	require.True(t, registration.Public)
+
+	other, err := config.RegisterAccess(context.Background(), access, nil)
+	require.NoError(t, err)
+	require.NotEqual(t, credentials.AccessKeyID, other.AccessKeyID)
+
+	_, ok = server.Registration("missing")
+	require.False(t, ok)
+}
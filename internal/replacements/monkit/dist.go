@@ -0,0 +1,167 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package monkit
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// IntDist keeps track of a distribution of integers.
+type IntDist struct {
+	Key    SeriesKey
+	Count  int64
+	Sum    int64
+	Low    int64
+	High   int64
+	Recent int64
+}
+
+func newIntDist(key SeriesKey) *IntDist {
+	return &IntDist{Key: key}
+}
+
+// Insert adds a value to the distribution.
+func (d *IntDist) Insert(val int64) {
+	if d.Count == 0 || val < d.Low {
+		d.Low = val
+	}
+	if d.Count == 0 || val > d.High {
+		d.High = val
+	}
+	d.Count++
+	d.Sum += val
+	d.Recent = val
+}
+
+// Copy returns a copy of the distribution.
+func (d *IntDist) Copy() *IntDist {
+	c := *d
+	return &c
+}
+
+// Stats reports the statistics of the distribution.
+func (d *IntDist) Stats(cb func(key SeriesKey, field string, val float64)) {
+	cb(d.Key, "count", float64(d.Count))
+	if d.Count == 0 {
+		return
+	}
+	cb(d.Key, "sum", float64(d.Sum))
+	cb(d.Key, "min", float64(d.Low))
+	cb(d.Key, "max", float64(d.High))
+	cb(d.Key, "avg", float64(d.Sum)/float64(d.Count))
+	cb(d.Key, "recent", float64(d.Recent))
+}
+
+// DurationDist keeps track of a distribution of durations, the statistics are
+// reported in seconds.
+type DurationDist struct {
+	Key    SeriesKey
+	Count  int64
+	Sum    time.Duration
+	Low    time.Duration
+	High   time.Duration
+	Recent time.Duration
+}
+
+func newDurationDist(key SeriesKey) *DurationDist {
+	return &DurationDist{Key: key}
+}
+
+// Insert adds a value to the distribution.
+func (d *DurationDist) Insert(val time.Duration) {
+	if d.Count == 0 || val < d.Low {
+		d.Low = val
+	}
+	if d.Count == 0 || val > d.High {
+		d.High = val
+	}
+	d.Count++
+	d.Sum += val
+	d.Recent = val
+}
+
+// Copy returns a copy of the distribution.
+func (d *DurationDist) Copy() *DurationDist {
+	c := *d
+	return &c
+}
+
+// Stats reports the statistics of the distribution.
+func (d *DurationDist) Stats(cb func(key SeriesKey, field string, val float64)) {
+	cb(d.Key, "count", float64(d.Count))
+	if d.Count == 0 {
+		return
+	}
+	cb(d.Key, "sum", d.Sum.Seconds())
+	cb(d.Key, "min", d.Low.Seconds())
+	cb(d.Key, "max", d.High.Seconds())
+	cb(d.Key, "avg", d.Sum.Seconds()/float64(d.Count))
+	cb(d.Key, "recent", d.Recent.Seconds())
+}
+
+// IntVal keeps statistics about observed integers.
+type IntVal struct {
+	mu   sync.Mutex
+	dist *IntDist
+}
+
+// NewIntVal creates a new int distribution.
+func NewIntVal(key SeriesKey) *IntVal {
+	return &IntVal{dist: newIntDist(key)}
+}
+
+// Observe records a value.
+func (v *IntVal) Observe(val int64) {
+	v.mu.Lock()
+	v.dist.Insert(val)
+	v.mu.Unlock()
+}
+
+// Stats reports the statistics of the observed values.
+func (v *IntVal) Stats(cb func(key SeriesKey, field string, val float64)) {
+	v.mu.Lock()
+	dist := v.dist.Copy()
+	v.mu.Unlock()
+
+	dist.Stats(cb)
+}
+
+// DurationVal keeps statistics about observed durations.
+type DurationVal struct {
+	mu   sync.Mutex
+	dist *DurationDist
+}
+
+// NewDurationVal creates a new duration distribution.
+func NewDurationVal(key SeriesKey) *DurationVal {
+	return &DurationVal{dist: newDurationDist(key)}
+}
+
+// Observe records a duration.
+func (v *DurationVal) Observe(val time.Duration) {
+	v.mu.Lock()
+	v.dist.Insert(val)
+	v.mu.Unlock()
+}
+
+// Stats reports the statistics of the observed durations.
+func (v *DurationVal) Stats(cb func(key SeriesKey, field string, val float64)) {
+	v.mu.Lock()
+	dist := v.dist.Copy()
+	v.mu.Unlock()
+
+	dist.Stats(cb)
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m map[string]int64) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,156 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package monkit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Task is a function that starts measuring a function call, the result must
+// be called with the error when the call finishes.
+type Task func(ctx *context.Context, args ...interface{}) func(*error)
+
+// Func keeps statistics about the calls of a single function.
+type Func struct {
+	scope *Scope
+	name  string
+	key   SeriesKey
+
+	mu        sync.Mutex
+	current   int64
+	highwater int64
+	errors    map[string]int64
+	success   *DurationDist
+	failure   *DurationDist
+}
+
+func newFunc(scope *Scope, name string, key SeriesKey) *Func {
+	return &Func{
+		scope:   scope,
+		name:    name,
+		key:     key,
+		errors:  map[string]int64{},
+		success: newDurationDist(key.WithTag("kind", "success")),
+		failure: newDurationDist(key.WithTag("kind", "failure")),
+	}
+}
+
+// Scope returns the scope of the function.
+func (f *Func) Scope() *Scope { return f.scope }
+
+// ShortName returns the name of the function.
+func (f *Func) ShortName() string { return f.name }
+
+// FullName returns the scope and name of the function.
+func (f *Func) FullName() string { return f.scope.name + "." + f.name }
+
+// Current returns the number of calls in progress.
+func (f *Func) Current() int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.current
+}
+
+// Task starts measuring a call of the function and adds a span to ctx.
+func (f *Func) Task(ctx *context.Context, args ...interface{}) func(*error) {
+	return f.start(ctx, nil, nil)
+}
+
+// RestartTrace is like Task, except it always starts a new trace.
+func (f *Func) RestartTrace(ctx *context.Context, args ...interface{}) func(*error) {
+	return f.start(ctx, NewTrace(NewId()), nil)
+}
+
+// ResetTrace is like Task, except it always starts a new trace.
+func (f *Func) ResetTrace(ctx *context.Context, args ...interface{}) func(*error) {
+	return f.start(ctx, NewTrace(NewId()), nil)
+}
+
+// RemoteTrace is like Task, except the span belongs to trace and has a
+// remote parent with the specified id.
+func (f *Func) RemoteTrace(ctx *context.Context, parentId int64, trace *Trace, args ...interface{}) func(*error) {
+	return f.start(ctx, trace, &parentId)
+}
+
+// start marks the beginning of a call.
+func (f *Func) start(ctx *context.Context, trace *Trace, parentId *int64) func(*error) {
+	f.mu.Lock()
+	f.current++
+	if f.current > f.highwater {
+		f.highwater = f.current
+	}
+	f.mu.Unlock()
+
+	parent := context.Background()
+	if ctx != nil && *ctx != nil {
+		parent = *ctx
+	}
+	span, finish := newSpan(parent, f, trace, parentId)
+	if ctx != nil {
+		*ctx = span
+	}
+
+	return func(errptr *error) {
+		var err error
+		if errptr != nil {
+			err = *errptr
+		}
+		f.end(err, span.Duration())
+		finish(err)
+	}
+}
+
+// end marks the end of a call.
+func (f *Func) end(err error, duration time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.current--
+	if err == nil {
+		f.success.Insert(duration)
+		return
+	}
+	f.errors[errorName(err)]++
+	f.failure.Insert(duration)
+}
+
+// Stats reports the statistics of the function.
+func (f *Func) Stats(cb func(key SeriesKey, field string, val float64)) {
+	f.mu.Lock()
+	current, highwater := f.current, f.highwater
+	errors := make(map[string]int64, len(f.errors))
+	for name, count := range f.errors {
+		errors[name] = count
+	}
+	success, failure := f.success.Copy(), f.failure.Copy()
+	f.mu.Unlock()
+
+	cb(f.key, "current", float64(current))
+	cb(f.key, "highwater", float64(highwater))
+	cb(f.key, "successes", float64(success.Count))
+
+	var errorCount int64
+	for _, name := range sortedKeys(errors) {
+		errorCount += errors[name]
+		cb(f.key.WithTag("error_name", name), "count", float64(errors[name]))
+	}
+	cb(f.key, "errors", float64(errorCount))
+	cb(f.key, "total", float64(success.Count+errorCount))
+
+	success.Stats(cb)
+	failure.Stats(cb)
+}
+
+// errorName returns a short name for the error.
+func errorName(err error) string {
+	switch err {
+	case context.Canceled:
+		return "Canceled"
+	case context.DeadlineExceeded:
+		return "Timeout"
+	}
+	return "Error"
+}
@@ -0,0 +1,103 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package monkit
+
+import (
+	"sync"
+	"time"
+)
+
+// Meter counts events and reports the total and the average rate.
+type Meter struct {
+	key SeriesKey
+
+	mu      sync.Mutex
+	total   int64
+	created time.Time
+}
+
+// NewMeter creates a new meter.
+func NewMeter(key SeriesKey) *Meter {
+	return &Meter{key: key, created: time.Now()}
+}
+
+// Mark records n events.
+func (m *Meter) Mark(n int) {
+	m.Mark64(int64(n))
+}
+
+// Mark64 records n events.
+func (m *Meter) Mark64(n int64) {
+	m.mu.Lock()
+	m.total += n
+	m.mu.Unlock()
+}
+
+// Stats reports the statistics of the meter.
+func (m *Meter) Stats(cb func(key SeriesKey, field string, val float64)) {
+	m.mu.Lock()
+	total := m.total
+	elapsed := time.Since(m.created).Seconds()
+	m.mu.Unlock()
+
+	rate := 0.0
+	if elapsed > 0 {
+		rate = float64(total) / elapsed
+	}
+
+	cb(m.key, "rate", rate)
+	cb(m.key, "total", float64(total))
+}
+
+// Counter keeps track of a value that goes up and down.
+type Counter struct {
+	key SeriesKey
+
+	mu        sync.Mutex
+	value     int64
+	low, high int64
+}
+
+// NewCounter creates a new counter.
+func NewCounter(key SeriesKey) *Counter {
+	return &Counter{key: key}
+}
+
+// Inc increments the counter by delta.
+func (c *Counter) Inc(delta int64) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.value += delta
+	if c.value > c.high {
+		c.high = c.value
+	}
+	if c.value < c.low {
+		c.low = c.value
+	}
+	return c.value
+}
+
+// Dec decrements the counter by delta.
+func (c *Counter) Dec(delta int64) int64 {
+	return c.Inc(-delta)
+}
+
+// Current returns the current value.
+func (c *Counter) Current() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.value
+}
+
+// Stats reports the statistics of the counter.
+func (c *Counter) Stats(cb func(key SeriesKey, field string, val float64)) {
+	c.mu.Lock()
+	value, low, high := c.value, c.low, c.high
+	c.mu.Unlock()
+
+	cb(c.key, "high", float64(high))
+	cb(c.key, "low", float64(low))
+	cb(c.key, "value", float64(value))
+}
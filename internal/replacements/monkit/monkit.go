@@ -1,122 +1,292 @@
 // Copyright (C) 2020 Storj Labs, Inc.
 // See LICENSE for copying information.
 
-// package monkit is not a real monkit package. it's a reimplementation that
-// does nothing, to avoid apache v2 vs gpl v2 licensing incompatibility. it also
-// means there are no metrics or monkit collection
+// package monkit is not a real monkit package. it's a minimal reimplementation
+// of the parts of the monkit api that are used by uplink, to avoid apache v2
+// vs gpl v2 licensing incompatibility.
+//
+// it records tasks, meters, counters and value distributions in process and
+// reports them through Registry.Stats. spans are only reported to trace
+// observers, there is no trace collection.
 package monkit
 
-import "context"
+import (
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
 
-// Scope
-type Scope struct{}
+// StatSource is something that reports statistics.
+type StatSource interface {
+	Stats(cb func(key SeriesKey, field string, val float64))
+}
+
+// Registry keeps track of all the scopes.
+type Registry struct {
+	mu     sync.Mutex
+	scopes map[string]*Scope
+
+	watchersMu sync.Mutex
+	watchers   []*func(*Trace)
+}
 
-// Package
-func Package() *Scope { return &Scope{} }
+// NewRegistry creates a new registry.
+func NewRegistry() *Registry {
+	return &Registry{scopes: map[string]*Scope{}}
+}
 
-// ScopeNamed
-func ScopeNamed(string) *Scope { return &Scope{} }
+// Default is the default registry.
+var Default = NewRegistry()
 
-// Event
-func (s *Scope) Event(string) {}
+// ScopeNamed returns the scope with the specified name.
+func (r *Registry) ScopeNamed(name string) *Scope {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-// Task
-func (s *Scope) Task() func(*context.Context, ...interface{}) func(*error) {
-	return func(*context.Context, ...interface{}) func(*error) {
-		return func(*error) {}
+	scope, ok := r.scopes[name]
+	if !ok {
+		scope = newScope(r, name)
+		r.scopes[name] = scope
 	}
+	return scope
 }
 
-// TaskNamed
-func (s *Scope) TaskNamed(string) func(*context.Context) func(*error) {
-	return func(*context.Context) func(*error) {
-		return func(*error) {}
+// ObserveTraces calls cb for every new trace until cancel is called.
+func (r *Registry) ObserveTraces(cb func(*Trace)) (cancel func()) {
+	ref := &cb
+
+	r.watchersMu.Lock()
+	r.watchers = append(r.watchers, ref)
+	r.watchersMu.Unlock()
+
+	return func() {
+		r.watchersMu.Lock()
+		defer r.watchersMu.Unlock()
+		for i, existing := range r.watchers {
+			if existing == ref {
+				r.watchers = append(r.watchers[:i:i], r.watchers[i+1:]...)
+				return
+			}
+		}
 	}
 }
 
-// Meter
-type Meter struct{}
+// observeTrace notifies the trace watchers about a new trace.
+func (r *Registry) observeTrace(t *Trace) {
+	r.watchersMu.Lock()
+	watchers := append([]*func(*Trace){}, r.watchers...)
+	r.watchersMu.Unlock()
 
-// Mark
-func (m *Meter) Mark(int64) {}
+	for _, watcher := range watchers {
+		(*watcher)(t)
+	}
+}
 
-// Meter
-func (s *Scope) Meter(string) *Meter { return &Meter{} }
+// Package returns the scope for the calling package.
+func (r *Registry) Package() *Scope {
+	return r.ScopeNamed(callerPackage(1))
+}
 
-// IntVal
-type IntVal struct{}
+// Stats reports the statistics of all scopes, sorted by scope name.
+func (r *Registry) Stats(cb func(key SeriesKey, field string, val float64)) {
+	r.mu.Lock()
+	scopes := make([]*Scope, 0, len(r.scopes))
+	for _, scope := range r.scopes {
+		scopes = append(scopes, scope)
+	}
+	r.mu.Unlock()
 
-// Observe
-func (v *IntVal) Observe(int64) {}
+	sort.Slice(scopes, func(i, k int) bool { return scopes[i].name < scopes[k].name })
+	for _, scope := range scopes {
+		scope.Stats(cb)
+	}
+}
 
-// IntVal
-func (s *Scope) IntVal(string) *IntVal { return &IntVal{} }
+// Package returns the scope for the calling package in the default registry.
+func Package() *Scope { return Default.ScopeNamed(callerPackage(1)) }
 
-// Func
-type Func struct{}
+// ScopeNamed returns the scope with the specified name in the default registry.
+func ScopeNamed(name string) *Scope { return Default.ScopeNamed(name) }
 
-// FuncNamed
-func (s *Scope) FuncNamed(string) *Func { return &Func{} }
+// Scope is a named group of metrics.
+type Scope struct {
+	registry *Registry
+	name     string
 
-// Func
-func (s *Scope) Func() *Func { return &Func{} }
+	mu      sync.Mutex
+	sources map[string]StatSource
 
-// RestartTrace
-func (f *Func) RestartTrace(*context.Context) func(*error) {
-	return func(*error) {}
+	// callers caches the *Func for the program counters that called Task.
+	callers sync.Map
 }
 
-// RemoteTrace
-func (f *Func) RemoteTrace(*context.Context, int64, *Trace) func(*error) {
-	return func(*error) {}
+func newScope(registry *Registry, name string) *Scope {
+	return &Scope{
+		registry: registry,
+		name:     name,
+		sources:  map[string]StatSource{},
+	}
 }
 
-// Trace
-type Trace struct{}
+// Name returns the scope name.
+func (s *Scope) Name() string { return s.name }
 
-// NewTrace
-func NewTrace(int64) *Trace { return &Trace{} }
+// get returns the stat source with the specified kind and name, creating it
+// with create when missing.
+func (s *Scope) get(kind, name string, create func() StatSource) StatSource {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-// Get
-func (t *Trace) Get(interface{}) interface{} { return nil }
+	id := kind + " " + name
+	source, ok := s.sources[id]
+	if !ok {
+		source = create()
+		s.sources[id] = source
+	}
+	return source
+}
 
-// Id
-func (t *Trace) Id() int64 { return 0 }
+// Stats reports the statistics of all metrics in the scope.
+func (s *Scope) Stats(cb func(key SeriesKey, field string, val float64)) {
+	s.mu.Lock()
+	ids := make([]string, 0, len(s.sources))
+	for id := range s.sources {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	sources := make([]StatSource, 0, len(ids))
+	for _, id := range ids {
+		sources = append(sources, s.sources[id])
+	}
+	s.mu.Unlock()
 
-// NewId
-func NewId() int64 { return 0 }
+	for _, source := range sources {
+		source.Stats(cb)
+	}
+}
 
-// Span
-type Span struct{}
+// seriesKey returns the key for a metric in the scope.
+func (s *Scope) seriesKey(measurement string) SeriesKey {
+	return NewSeriesKey(measurement).WithTag("scope", s.name)
+}
 
-// SpanFromCtx
-func SpanFromCtx(context.Context) *Span {
-	return &Span{}
+// Event marks an event with the specified name.
+func (s *Scope) Event(name string, tags ...SeriesTag) {
+	s.Meter(name, tags...).Mark(1)
 }
 
-// Parent
-func (s *Span) Parent() *Span { return &Span{} }
+// Meter returns the meter with the specified name.
+func (s *Scope) Meter(name string, tags ...SeriesTag) *Meter {
+	key := s.seriesKey(name).WithTags(tags...)
+	return s.get("meter", key.String(), func() StatSource {
+		return NewMeter(key)
+	}).(*Meter)
+}
 
-// Trace
-func (s *Span) Trace() *Trace { return &Trace{} }
+// Counter returns the counter with the specified name.
+func (s *Scope) Counter(name string, tags ...SeriesTag) *Counter {
+	key := s.seriesKey(name).WithTags(tags...)
+	return s.get("counter", key.String(), func() StatSource {
+		return NewCounter(key)
+	}).(*Counter)
+}
 
-// Id
-func (s *Span) Id() int64 { return 0 }
+// IntVal returns the int distribution with the specified name.
+func (s *Scope) IntVal(name string, tags ...SeriesTag) *IntVal {
+	key := s.seriesKey(name).WithTags(tags...)
+	return s.get("intval", key.String(), func() StatSource {
+		return NewIntVal(key)
+	}).(*IntVal)
+}
 
-// Registry
-type Registry struct{}
+// DurationVal returns the duration distribution with the specified name.
+func (s *Scope) DurationVal(name string, tags ...SeriesTag) *DurationVal {
+	key := s.seriesKey(name).WithTags(tags...)
+	return s.get("durationval", key.String(), func() StatSource {
+		return NewDurationVal(key)
+	}).(*DurationVal)
+}
 
-// Default
-var Default = &Registry{}
+// FuncNamed returns the function statistics with the specified name.
+func (s *Scope) FuncNamed(name string, tags ...SeriesTag) *Func {
+	key := NewSeriesKey("function").WithTag("name", name).WithTag("scope", s.name).WithTags(tags...)
+	return s.get("function", key.String(), func() StatSource {
+		return newFunc(s, name, key)
+	}).(*Func)
+}
+
+// Func returns the function statistics for the calling function.
+func (s *Scope) Func() *Func {
+	return s.FuncNamed(callerFunc(1))
+}
+
+// Task returns a task for the calling function.
+func (s *Scope) Task(tags ...SeriesTag) Task {
+	if len(tags) > 0 {
+		return s.FuncNamed(callerFunc(1), tags...).Task
+	}
+
+	pc, _, _, ok := runtime.Caller(1)
+	if !ok {
+		return s.FuncNamed("unknown").Task
+	}
+	if f, ok := s.callers.Load(pc); ok {
+		return f.(*Func).Task
+	}
+
+	f := s.FuncNamed(funcName(pc))
+	s.callers.Store(pc, f)
+	return f.Task
+}
+
+// TaskNamed returns a task with the specified name.
+func (s *Scope) TaskNamed(name string, tags ...SeriesTag) Task {
+	return s.FuncNamed(name, tags...).Task
+}
 
-// Stats
-func (r *Registry) Stats(cb func(SeriesKey, string, float64)) {}
+// callerFunc returns the short name of the function skip frames above the
+// caller of callerFunc.
+func callerFunc(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return "unknown"
+	}
+	return funcName(pc)
+}
 
-// SeriesKey
-type SeriesKey struct{}
+// funcName returns the name of the function at pc without the package path.
+func funcName(pc uintptr) string {
+	name := fullName(pc)
+	if i := strings.LastIndexByte(name, '/'); i >= 0 {
+		name = name[i+1:]
+	}
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
 
-// WithField
-func (k *SeriesKey) WithField(string) string { return "" }
+// callerPackage returns the package path of the function skip frames above
+// the caller of callerPackage.
+func callerPackage(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return "unknown"
+	}
+	name := fullName(pc)
+	slash := strings.LastIndexByte(name, '/')
+	if i := strings.IndexByte(name[slash+1:], '.'); i >= 0 {
+		name = name[:slash+1+i]
+	}
+	return name
+}
 
-// ResetContextSpan
-func ResetContextSpan(ctx context.Context) context.Context { return ctx }
+// fullName returns the fully qualified name of the function at pc.
+func fullName(pc uintptr) string {
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return "unknown"
+	}
+	return fn.Name()
+}
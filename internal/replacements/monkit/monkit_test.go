@@ -0,0 +1,124 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package monkit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRegistryStats(t *testing.T) {
+	registry := NewRegistry()
+	scope := registry.ScopeNamed("test")
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		var err error
+		if i == 2 {
+			err = errors.New("failure")
+		}
+		scope.TaskNamed("work")(&ctx)(&err)
+	}
+	scope.Meter("bytes").Mark(10)
+	scope.Counter("open").Inc(2)
+	scope.IntVal("size").Observe(5)
+	scope.IntVal("size").Observe(7)
+	scope.DurationVal("latency").Observe(2 * time.Second)
+
+	stats := map[string]float64{}
+	registry.Stats(func(key SeriesKey, field string, val float64) {
+		stats[key.WithField(field)] = val
+	})
+
+	expected := map[string]float64{
+		"function,name=work,scope=test successes":              2,
+		"function,name=work,scope=test errors":                 1,
+		"function,name=work,scope=test total":                  3,
+		"function,error_name=Error,name=work,scope=test count": 1,
+		"function,kind=success,name=work,scope=test count":     2,
+		"bytes,scope=test total":                               10,
+		"open,scope=test value":                                2,
+		"size,scope=test avg":                                  6,
+		"size,scope=test max":                                  7,
+		"latency,scope=test sum":                               2,
+	}
+	for key, value := range expected {
+		got, ok := stats[key]
+		if !ok {
+			t.Errorf("missing %q", key)
+			continue
+		}
+		if got != value {
+			t.Errorf("%q: got %v, expected %v", key, got, value)
+		}
+	}
+}
+
+func TestTaskName(t *testing.T) {
+	scope := NewRegistry().ScopeNamed("test")
+
+	ctx := context.Background()
+	scope.Task()(&ctx)(nil)
+
+	if scope.Func().ShortName() != "TestTaskName" {
+		t.Errorf("unexpected name %q", scope.Func().ShortName())
+	}
+	if scope.Func().Current() != 0 {
+		t.Errorf("unexpected current %d", scope.Func().Current())
+	}
+}
+
+type recordingObserver struct {
+	started  []*Span
+	finished []error
+}
+
+func (o *recordingObserver) Start(s *Span) { o.started = append(o.started, s) }
+
+func (o *recordingObserver) Finish(s *Span, err error, panicked bool, finish time.Time) {
+	o.finished = append(o.finished, err)
+}
+
+func TestSpans(t *testing.T) {
+	registry := NewRegistry()
+	scope := registry.ScopeNamed("test")
+
+	observer := &recordingObserver{}
+	cancel := registry.ObserveTraces(func(trace *Trace) {
+		trace.ObserveSpans(observer)
+	})
+	defer cancel()
+
+	ctx := context.Background()
+	trace := NewTrace(42)
+	finishOuter := scope.FuncNamed("outer").RemoteTrace(&ctx, 7, trace)
+
+	innerCtx := ctx
+	failure := errors.New("failure")
+	scope.TaskNamed("inner")(&innerCtx)(&failure)
+	finishOuter(nil)
+
+	if len(observer.started) != 2 || len(observer.finished) != 2 {
+		t.Fatalf("unexpected span count %d, %d", len(observer.started), len(observer.finished))
+	}
+
+	outer, inner := observer.started[0], observer.started[1]
+	if outer.Trace().Id() != 42 || inner.Trace().Id() != 42 {
+		t.Errorf("unexpected trace ids %d, %d", outer.Trace().Id(), inner.Trace().Id())
+	}
+	if parentId, ok := outer.ParentId(); !ok || parentId != 7 {
+		t.Errorf("unexpected outer parent %d", parentId)
+	}
+	if parentId, ok := inner.ParentId(); !ok || parentId != outer.Id() {
+		t.Errorf("unexpected inner parent %d", parentId)
+	}
+	if observer.finished[0] != failure || observer.finished[1] != nil {
+		t.Errorf("unexpected errors %v", observer.finished)
+	}
+	if SpanFromCtx(ResetContextSpan(innerCtx)) != nil {
+		t.Errorf("span not reset")
+	}
+}
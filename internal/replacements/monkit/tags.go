@@ -0,0 +1,138 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package monkit
+
+import (
+	"sort"
+	"strings"
+)
+
+// SeriesTag is a key/value pair attached to a series.
+type SeriesTag struct {
+	Key, Value string
+}
+
+// NewSeriesTag creates a new series tag.
+func NewSeriesTag(key, value string) SeriesTag {
+	return SeriesTag{Key: key, Value: value}
+}
+
+// TagSet is an immutable collection of tags.
+type TagSet struct {
+	all map[string]string
+}
+
+// Get returns the value of the tag.
+func (t *TagSet) Get(key string) string {
+	if t == nil {
+		return ""
+	}
+	return t.all[key]
+}
+
+// All returns a copy of all tags.
+func (t *TagSet) All() map[string]string {
+	all := map[string]string{}
+	if t != nil {
+		for key, value := range t.all {
+			all[key] = value
+		}
+	}
+	return all
+}
+
+// Len returns the number of tags.
+func (t *TagSet) Len() int {
+	if t == nil {
+		return 0
+	}
+	return len(t.all)
+}
+
+// Set returns a new tag set with the tag added.
+func (t *TagSet) Set(key, value string) *TagSet {
+	all := t.All()
+	all[key] = value
+	return &TagSet{all: all}
+}
+
+// SetTags returns a new tag set with the tags added.
+func (t *TagSet) SetTags(tags ...SeriesTag) *TagSet {
+	all := t.All()
+	for _, tag := range tags {
+		all[tag.Key] = tag.Value
+	}
+	return &TagSet{all: all}
+}
+
+// String returns the tags sorted by key in the form key=value,... .
+func (t *TagSet) String() string {
+	if t.Len() == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(t.all))
+	for key := range t.all {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for i, key := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(escape(key))
+		b.WriteByte('=')
+		b.WriteString(escape(t.all[key]))
+	}
+	return b.String()
+}
+
+// SeriesKey identifies a series by measurement and tags.
+type SeriesKey struct {
+	Measurement string
+	Tags        *TagSet
+}
+
+// NewSeriesKey creates a new series key without tags.
+func NewSeriesKey(measurement string) SeriesKey {
+	return SeriesKey{Measurement: measurement}
+}
+
+// WithTag returns a copy of the key with the tag added.
+func (s SeriesKey) WithTag(key, value string) SeriesKey {
+	s.Tags = s.Tags.Set(key, value)
+	return s
+}
+
+// WithTags returns a copy of the key with the tags added.
+func (s SeriesKey) WithTags(tags ...SeriesTag) SeriesKey {
+	if len(tags) == 0 {
+		return s
+	}
+	s.Tags = s.Tags.SetTags(tags...)
+	return s
+}
+
+// String returns the key in the form measurement,key=value,... .
+func (s SeriesKey) String() string {
+	tags := s.Tags.String()
+	if tags == "" {
+		return escape(s.Measurement)
+	}
+	return escape(s.Measurement) + "," + tags
+}
+
+// WithField returns the key together with the field name.
+func (s SeriesKey) WithField(field string) string {
+	return s.String() + " " + escape(field)
+}
+
+// escaper escapes the characters that separate parts of a series key.
+var escaper = strings.NewReplacer(`\`, `\\`, `,`, `\,`, ` `, `\ `, `=`, `\=`)
+
+// escape escapes the separators in s.
+func escape(s string) string {
+	return escaper.Replace(s)
+}
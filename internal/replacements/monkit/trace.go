@@ -0,0 +1,217 @@
+// Copyright (C) 2020 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package monkit
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// SpanObserver observes the spans of a trace as they start and finish.
+type SpanObserver interface {
+	// Start is called when a Span starts.
+	Start(s *Span)
+	// Finish is called when a Span finishes.
+	Finish(s *Span, err error, panicked bool, finish time.Time)
+}
+
+// Trace is the collection of spans started from the same root span.
+type Trace struct {
+	id int64
+
+	mu        sync.Mutex
+	vals      map[interface{}]interface{}
+	observers []*SpanObserver
+}
+
+// NewTrace returns a new trace.
+func NewTrace(id int64) *Trace { return &Trace{id: id} }
+
+// Get returns the value associated with key.
+func (t *Trace) Get(key interface{}) interface{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.vals[key]
+}
+
+// Set associates val with key.
+func (t *Trace) Set(key, val interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.vals == nil {
+		t.vals = map[interface{}]interface{}{}
+	}
+	t.vals[key] = val
+}
+
+// Id returns the trace id.
+func (t *Trace) Id() int64 { return t.id }
+
+// ObserveSpans registers observer for all future spans of the trace.
+func (t *Trace) ObserveSpans(observer SpanObserver) (cancel func()) {
+	ref := &observer
+
+	t.mu.Lock()
+	t.observers = append(t.observers, ref)
+	t.mu.Unlock()
+
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		for i, existing := range t.observers {
+			if existing == ref {
+				t.observers = append(t.observers[:i:i], t.observers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// spanObservers returns the current observers of the trace.
+func (t *Trace) spanObservers() []SpanObserver {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	observers := make([]SpanObserver, 0, len(t.observers))
+	for _, ref := range t.observers {
+		observers = append(observers, *ref)
+	}
+	return observers
+}
+
+var (
+	idMu  sync.Mutex
+	idRng = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// NewId returns a new random id.
+func NewId() int64 {
+	idMu.Lock()
+	defer idMu.Unlock()
+	return idRng.Int63()
+}
+
+type spanKey struct{}
+
+// Span is a single call of a function in a trace.
+type Span struct {
+	context.Context
+
+	id       int64
+	parent   *Span
+	parentId *int64
+	f        *Func
+	trace    *Trace
+	start    time.Time
+}
+
+// SpanFromCtx returns the span in ctx or nil when there's none.
+func SpanFromCtx(ctx context.Context) *Span {
+	if s, ok := ctx.(*Span); ok && s != nil {
+		return s
+	}
+	if s, ok := ctx.Value(spanKey{}).(*Span); ok && s != nil {
+		return s
+	}
+	return nil
+}
+
+// Value returns the span itself for the span key and otherwise the value
+// from the context the span was started with.
+func (s *Span) Value(key interface{}) interface{} {
+	if key == (spanKey{}) {
+		return s
+	}
+	return s.Context.Value(key)
+}
+
+// Parent returns the local parent span or nil.
+func (s *Span) Parent() *Span { return s.parent }
+
+// ParentId returns the id of the parent span, if it has one.
+func (s *Span) ParentId() (int64, bool) {
+	if s.parentId != nil {
+		return *s.parentId, true
+	}
+	if s.parent != nil {
+		return s.parent.id, true
+	}
+	return 0, false
+}
+
+// Trace returns the trace of the span.
+func (s *Span) Trace() *Trace { return s.trace }
+
+// Id returns the span id.
+func (s *Span) Id() int64 { return s.id }
+
+// Func returns the function that started the span.
+func (s *Span) Func() *Func { return s.f }
+
+// Start returns when the span started.
+func (s *Span) Start() time.Time { return s.start }
+
+// Duration returns how long the span has been running.
+func (s *Span) Duration() time.Duration { return time.Since(s.start) }
+
+// newSpan starts a new span for f in ctx. When trace is nil, the span
+// continues the trace of the span in ctx, or starts a new trace.
+func newSpan(ctx context.Context, f *Func, trace *Trace, parentId *int64) (*Span, func(error)) {
+	parent := SpanFromCtx(ctx)
+	if trace == nil {
+		if parent != nil {
+			trace = parent.trace
+		} else {
+			trace = NewTrace(NewId())
+			f.scope.registry.observeTrace(trace)
+		}
+	} else {
+		f.scope.registry.observeTrace(trace)
+	}
+	if parentId != nil || parent == nil || parent.trace != trace {
+		parent = nil
+	}
+
+	s := &Span{
+		Context:  ctx,
+		id:       NewId(),
+		parent:   parent,
+		parentId: parentId,
+		f:        f,
+		trace:    trace,
+		start:    time.Now(),
+	}
+
+	observers := trace.spanObservers()
+	for _, observer := range observers {
+		observer.Start(s)
+	}
+
+	return s, func(err error) {
+		finish := time.Now()
+		for _, observer := range observers {
+			observer.Finish(s, err, false, finish)
+		}
+	}
+}
+
+// resetContext hides the span of the wrapped context.
+type resetContext struct {
+	context.Context
+}
+
+// Value returns nil for the span key.
+func (r resetContext) Value(key interface{}) interface{} {
+	if key == (spanKey{}) {
+		return nil
+	}
+	return r.Context.Value(key)
+}
+
+// ResetContextSpan returns ctx without the current span.
+func ResetContextSpan(ctx context.Context) context.Context {
+	return resetContext{ctx}
+}
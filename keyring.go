@@ -0,0 +1,399 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/common/encryption"
+	"storj.io/uplink"
+)
+
+// ErrKeyring is returned when the keyring cannot be used.
+var ErrKeyring = errs.Class("keyring")
+
+// keyringVersion is the version of the keyring file format.
+const keyringVersion = 1
+
+// keyringMu serializes the keyring operations of this process.
+var keyringMu sync.Mutex
+
+// keyringFile is the content of a keyring file.
+//
+// Ciphertext is the JSON encoded list of entries, encrypted with AES-GCM
+// using a key derived from the passphrase and salt with argon2id. A new salt
+// and nonce are used for every write.
+type keyringFile struct {
+	Version    int    `json:"version"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// keyringEntry is a named access grant in the keyring.
+type keyringEntry struct {
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	SatelliteAddress string `json:"satellite_address"`
+	Access           string `json:"access"`
+	Created          int64  `json:"created"`
+	Updated          int64  `json:"updated"`
+}
+
+// uplink_keyring_save stores access in the keyring at path with name.
+//
+// The keyring file is created when it does not exist. An existing entry with
+// the same name is replaced. description is optional.
+//
+//export uplink_keyring_save
+func uplink_keyring_save(path, passphrase, name, description *C.uplink_const_char, access *C.UplinkAccess) *C.UplinkError {
+	if path == nil {
+		return mallocError(ErrNull.New("path"))
+	}
+	if passphrase == nil {
+		return mallocError(ErrNull.New("passphrase"))
+	}
+	if name == nil {
+		return mallocError(ErrNull.New("name"))
+	}
+	if access == nil {
+		return mallocError(ErrNull.New("access"))
+	}
+
+	acc, ok := universe.Get(access._handle).(*Access)
+	if !ok {
+		return mallocError(ErrInvalidHandle.New("access"))
+	}
+
+	goname := C.GoString(name)
+	if goname == "" {
+		return mallocError(ErrInvalidArg.New("name is empty"))
+	}
+
+	serialized, err := acc.Serialize()
+	if err != nil {
+		return mallocError(err)
+	}
+
+	var godescription string
+	if description != nil {
+		godescription = C.GoString(description)
+	}
+
+	err = keyringSave(C.GoString(path), C.GoString(passphrase), keyringEntry{
+		Name:             goname,
+		Description:      godescription,
+		SatelliteAddress: acc.SatelliteAddress(),
+		Access:           serialized,
+	})
+	return mallocError(err)
+}
+
+// uplink_keyring_load loads the access with name from the keyring at path.
+//
+//export uplink_keyring_load
+func uplink_keyring_load(path, passphrase, name *C.uplink_const_char) C.UplinkAccessResult {
+	if path == nil {
+		return C.UplinkAccessResult{
+			error: mallocError(ErrNull.New("path")),
+		}
+	}
+	if passphrase == nil {
+		return C.UplinkAccessResult{
+			error: mallocError(ErrNull.New("passphrase")),
+		}
+	}
+	if name == nil {
+		return C.UplinkAccessResult{
+			error: mallocError(ErrNull.New("name")),
+		}
+	}
+
+	entry, err := keyringLoad(C.GoString(path), C.GoString(passphrase), C.GoString(name))
+	if err != nil {
+		return C.UplinkAccessResult{
+			error: mallocError(err),
+		}
+	}
+
+	access, err := uplink.ParseAccess(entry.Access)
+	if err != nil {
+		return C.UplinkAccessResult{
+			error: mallocError(err),
+		}
+	}
+
+	return C.UplinkAccessResult{
+		access: (*C.UplinkAccess)(mallocHandle(universe.Add(&Access{Access: access}))),
+	}
+}
+
+// uplink_keyring_list lists the entries of the keyring at path, sorted by
+// name.
+//
+// A keyring file that does not exist has no entries.
+//
+//export uplink_keyring_list
+func uplink_keyring_list(path, passphrase *C.uplink_const_char) C.UplinkKeyringListResult {
+	if path == nil {
+		return C.UplinkKeyringListResult{
+			error: mallocError(ErrNull.New("path")),
+		}
+	}
+	if passphrase == nil {
+		return C.UplinkKeyringListResult{
+			error: mallocError(ErrNull.New("passphrase")),
+		}
+	}
+
+	keyringMu.Lock()
+	entries, err := readKeyring(C.GoString(path), C.GoString(passphrase))
+	keyringMu.Unlock()
+	if err != nil {
+		return C.UplinkKeyringListResult{
+			error: mallocError(err),
+		}
+	}
+
+	centries := (*C.UplinkKeyringEntry)(calloc(C.size_t(len(entries)), C.sizeof_UplinkKeyringEntry))
+	array := unsafe.Slice(centries, len(entries))
+	for i, entry := range entries {
+		array[i] = C.UplinkKeyringEntry{
+			name:              cstring(entry.Name),
+			description:       cstring(entry.Description),
+			satellite_address: cstring(entry.SatelliteAddress),
+			created:           C.int64_t(entry.Created),
+			updated:           C.int64_t(entry.Updated),
+		}
+	}
+
+	return C.UplinkKeyringListResult{
+		entries:       centries,
+		entries_count: C.size_t(len(entries)),
+	}
+}
+
+// uplink_free_keyring_list_result frees the resources associated with keyring list result.
+//
+//export uplink_free_keyring_list_result
+func uplink_free_keyring_list_result(result C.UplinkKeyringListResult) {
+	uplink_free_error(result.error)
+	if result.entries == nil {
+		return
+	}
+	defer free(unsafe.Pointer(result.entries))
+
+	array := unsafe.Slice(result.entries, int(result.entries_count))
+	for i := range array {
+		free(unsafe.Pointer(array[i].name))
+		free(unsafe.Pointer(array[i].description))
+		free(unsafe.Pointer(array[i].satellite_address))
+	}
+}
+
+// uplink_keyring_delete deletes the entry with name from the keyring at path.
+//
+//export uplink_keyring_delete
+func uplink_keyring_delete(path, passphrase, name *C.uplink_const_char) *C.UplinkError {
+	if path == nil {
+		return mallocError(ErrNull.New("path"))
+	}
+	if passphrase == nil {
+		return mallocError(ErrNull.New("passphrase"))
+	}
+	if name == nil {
+		return mallocError(ErrNull.New("name"))
+	}
+
+	return mallocError(keyringDelete(C.GoString(path), C.GoString(passphrase), C.GoString(name)))
+}
+
+// keyringSave adds or replaces entry in the keyring.
+func keyringSave(path, passphrase string, entry keyringEntry) error {
+	keyringMu.Lock()
+	defer keyringMu.Unlock()
+
+	entries, err := readKeyring(path, passphrase)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().Unix()
+	entry.Created, entry.Updated = now, now
+	for i := range entries {
+		if entries[i].Name == entry.Name {
+			entry.Created = entries[i].Created
+			entries = append(entries[:i], entries[i+1:]...)
+			break
+		}
+	}
+	entries = append(entries, entry)
+
+	return writeKeyring(path, passphrase, entries)
+}
+
+// keyringLoad returns the entry with name.
+func keyringLoad(path, passphrase, name string) (keyringEntry, error) {
+	keyringMu.Lock()
+	defer keyringMu.Unlock()
+
+	entries, err := readKeyring(path, passphrase)
+	if err != nil {
+		return keyringEntry{}, err
+	}
+	for _, entry := range entries {
+		if entry.Name == name {
+			return entry, nil
+		}
+	}
+	return keyringEntry{}, ErrKeyring.New("entry %q not found", name)
+}
+
+// keyringDelete deletes the entry with name.
+func keyringDelete(path, passphrase, name string) error {
+	keyringMu.Lock()
+	defer keyringMu.Unlock()
+
+	entries, err := readKeyring(path, passphrase)
+	if err != nil {
+		return err
+	}
+	for i := range entries {
+		if entries[i].Name == name {
+			return writeKeyring(path, passphrase, append(entries[:i], entries[i+1:]...))
+		}
+	}
+	return ErrKeyring.New("entry %q not found", name)
+}
+
+// readKeyring reads and decrypts the entries of the keyring, sorted by name.
+// It returns no entries when the file does not exist.
+func readKeyring(path, passphrase string) ([]keyringEntry, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, ErrKeyring.Wrap(err)
+	}
+
+	var file keyringFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, ErrKeyring.New("invalid keyring file: %v", err)
+	}
+	if file.Version != keyringVersion {
+		return nil, ErrKeyring.New("unsupported keyring version %d", file.Version)
+	}
+
+	aead, err := keyringCipher(passphrase, file.Salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(file.Nonce) != aead.NonceSize() {
+		return nil, ErrKeyring.New("invalid keyring file: invalid nonce")
+	}
+
+	plaintext, err := aead.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		return nil, ErrKeyring.New("invalid passphrase or corrupted keyring")
+	}
+
+	var entries []keyringEntry
+	if err := json.Unmarshal(plaintext, &entries); err != nil {
+		return nil, ErrKeyring.New("invalid keyring entries: %v", err)
+	}
+	sort.Slice(entries, func(i, k int) bool { return entries[i].Name < entries[k].Name })
+	return entries, nil
+}
+
+// writeKeyring encrypts and atomically replaces the keyring file. The file is
+// only readable by the owner.
+func writeKeyring(path, passphrase string, entries []keyringEntry) (err error) {
+	if entries == nil {
+		entries = []keyringEntry{}
+	}
+	plaintext, err := json.Marshal(entries)
+	if err != nil {
+		return ErrKeyring.Wrap(err)
+	}
+
+	file := keyringFile{
+		Version: keyringVersion,
+		Salt:    make([]byte, 16),
+	}
+	if _, err := rand.Read(file.Salt); err != nil {
+		return ErrKeyring.Wrap(err)
+	}
+
+	aead, err := keyringCipher(passphrase, file.Salt)
+	if err != nil {
+		return err
+	}
+	file.Nonce = make([]byte, aead.NonceSize())
+	if _, err := rand.Read(file.Nonce); err != nil {
+		return ErrKeyring.Wrap(err)
+	}
+	file.Ciphertext = aead.Seal(nil, file.Nonce, plaintext, nil)
+
+	data, err := json.Marshal(file)
+	if err != nil {
+		return ErrKeyring.Wrap(err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return ErrKeyring.Wrap(err)
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return ErrKeyring.Wrap(err)
+	}
+	if err := tmp.Sync(); err != nil {
+		_ = tmp.Close()
+		return ErrKeyring.Wrap(err)
+	}
+	if err := tmp.Close(); err != nil {
+		return ErrKeyring.Wrap(err)
+	}
+	return ErrKeyring.Wrap(os.Rename(tmp.Name(), path))
+}
+
+// keyringCipher returns the cipher for the passphrase and salt.
+func keyringCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := encryption.DeriveRootKey([]byte(passphrase), salt, "", 1)
+	if err != nil {
+		return nil, ErrKeyring.Wrap(err)
+	}
+
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, ErrKeyring.Wrap(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, ErrKeyring.Wrap(err)
+	}
+	return aead, nil
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyring(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keyring.json")
+
+	entries, err := readKeyring(path, "secret")
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	require.NoError(t, keyringSave(path, "secret", keyringEntry{Name: "beta", Access: "access-beta"}))
+	require.NoError(t, keyringSave(path, "secret", keyringEntry{Name: "alpha", Access: "access-alpha", Description: "first"}))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "access-alpha")
+
+	entries, err = readKeyring(path, "secret")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, "alpha", entries[0].Name)
+	require.Equal(t, "first", entries[0].Description)
+	require.Equal(t, "beta", entries[1].Name)
+	require.NotZero(t, entries[0].Created)
+
+	// saving again replaces the entry
+	require.NoError(t, keyringSave(path, "secret", keyringEntry{Name: "alpha", Access: "access-alpha-2"}))
+	entry, err := keyringLoad(path, "secret", "alpha")
+	require.NoError(t, err)
+	require.Equal(t, "access-alpha-2", entry.Access)
+	require.Equal(t, entries[0].Created, entry.Created)
+
+	_, err = readKeyring(path, "wrong")
+	require.True(t, ErrKeyring.Has(err))
+
+	_, err = keyringLoad(path, "secret", "missing")
+	require.True(t, ErrKeyring.Has(err))
+
+	require.NoError(t, keyringDelete(path, "secret", "alpha"))
+	require.True(t, ErrKeyring.Has(keyringDelete(path, "secret", "alpha")))
+
+	entries, err = readKeyring(path, "secret")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "beta", entries[0].Name)
+
+	matches, err := filepath.Glob(path + ".tmp*")
+	require.NoError(t, err)
+	require.Empty(t, matches)
+}
@@ -0,0 +1,173 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"github.com/spacemonkeygo/monkit/v3"
+)
+
+// logger is the log callback registered by the caller.
+type logger struct {
+	level    C.int32_t
+	callback C.UplinkLogCallback
+	userData unsafe.Pointer
+}
+
+var (
+	// currentLogger is nil when logging is disabled.
+	currentLogger atomic.Pointer[logger]
+	// observeLogTraces registers the span observer of the logger on first use.
+	observeLogTraces sync.Once
+)
+
+// uplink_set_log_callback sets the callback that receives diagnostic events
+// with at least the specified level. Passing NULL as callback disables logging.
+//
+// Besides the events of the exported calls, dialing, retried requests and
+// segment operations inside uplink are reported at UPLINK_LOG_LEVEL_DEBUG, or
+// at UPLINK_LOG_LEVEL_WARN when they failed.
+//
+// The callback may be called concurrently from multiple threads and must not
+// call back into the library.
+//
+//export uplink_set_log_callback
+func uplink_set_log_callback(level C.int32_t, callback C.UplinkLogCallback, user_data unsafe.Pointer) *C.UplinkError { //nolint:golint
+	if callback == nil {
+		currentLogger.Store(nil)
+		return nil
+	}
+
+	if level < C.UPLINK_LOG_LEVEL_DEBUG || level > C.UPLINK_LOG_LEVEL_ERROR {
+		return mallocError(ErrInvalidArg.New("unknown log level %d", level))
+	}
+
+	observeLogTraces.Do(func() {
+		monkit.Default.ObserveTraces(func(trace *monkit.Trace) {
+			trace.ObserveSpans(logSpanObserver{})
+		})
+	})
+
+	currentLogger.Store(&logger{
+		level:    level,
+		callback: callback,
+		userData: user_data,
+	})
+	return nil
+}
+
+func logDebug(message string, keyvals ...interface{}) {
+	logEvent(C.UPLINK_LOG_LEVEL_DEBUG, message, keyvals...)
+}
+
+func logInfo(message string, keyvals ...interface{}) {
+	logEvent(C.UPLINK_LOG_LEVEL_INFO, message, keyvals...)
+}
+
+func logWarn(message string, keyvals ...interface{}) {
+	logEvent(C.UPLINK_LOG_LEVEL_WARN, message, keyvals...)
+}
+
+func logError(message string, keyvals ...interface{}) {
+	logEvent(C.UPLINK_LOG_LEVEL_ERROR, message, keyvals...)
+}
+
+// logEvent sends message to the log callback, keyvals are alternating keys
+// and values of the fields.
+func logEvent(level C.int32_t, message string, keyvals ...interface{}) {
+	log := currentLogger.Load()
+	if log == nil || level < log.level {
+		return
+	}
+
+	count := (len(keyvals) + 1) / 2
+	fields := (*C.UplinkLogField)(calloc(C.size_t(count), C.sizeof_UplinkLogField))
+	defer free(unsafe.Pointer(fields))
+
+	array := unsafe.Slice(fields, count)
+	for i := range array {
+		key := fmt.Sprint(keyvals[2*i])
+		value := ""
+		if 2*i+1 < len(keyvals) {
+			value = fmt.Sprintf("%v", keyvals[2*i+1])
+		}
+
+		array[i].key = cstring(key)
+		array[i].value = cstring(value)
+	}
+	defer func() {
+		for i := range array {
+			free(unsafe.Pointer(array[i].key))
+			free(unsafe.Pointer(array[i].value))
+		}
+	}()
+
+	cmessage := cstring(message)
+	defer free(unsafe.Pointer(cmessage))
+
+	callLogCallback(log.callback, level, cmessage, fields, C.size_t(count), log.userData)
+}
+
+// logSpanObserver reports the spans of internal uplink events to the logger.
+type logSpanObserver struct{}
+
+// Start implements monkit.SpanObserver.
+func (logSpanObserver) Start(s *monkit.Span) {}
+
+// Finish implements monkit.SpanObserver.
+func (logSpanObserver) Finish(s *monkit.Span, err error, panicked bool, finish time.Time) {
+	if currentLogger.Load() == nil {
+		return
+	}
+
+	message, ok := internalEventMessage(s.Func())
+	if !ok {
+		return
+	}
+
+	keyvals := []interface{}{"func", s.Func().FullName(), "duration", finish.Sub(s.Start())}
+	if attrs, ok := s.Value(spanAttributesKey{}).(*spanAttributes); ok {
+		if attrs.bucket != "" {
+			keyvals = append(keyvals, "bucket", attrs.bucket)
+		}
+		if attrs.key != "" {
+			keyvals = append(keyvals, "key", attrs.key)
+		}
+	}
+
+	if err != nil && !errors.Is(err, io.EOF) {
+		logWarn(message, append(keyvals, "error", err)...)
+		return
+	}
+	logDebug(message, keyvals...)
+}
+
+// internalEventMessage returns the log message for spans of uplink, which
+// dial, retry requests or operate on segments.
+func internalEventMessage(f *monkit.Func) (string, bool) {
+	if f.Scope() == mon {
+		return "", false
+	}
+
+	name := strings.ToLower(f.ShortName())
+	switch {
+	case strings.Contains(name, "withretry"):
+		return "retried request", true
+	case strings.Contains(name, "dial"):
+		return "dialed", true
+	case strings.Contains(name, "segment"):
+		return "finished segment operation", true
+	}
+	return "", false
+}
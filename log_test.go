@@ -0,0 +1,30 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/spacemonkeygo/monkit/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInternalEventMessage(t *testing.T) {
+	for _, tc := range []struct {
+		scope, name string
+		message     string
+	}{
+		{"storj.io/common/rpc", "Dialer.DialNodeURL", "dialed"},
+		{"storj.io/common/rpc", "Dialer.dialEncryptedConn", "dialed"},
+		{"storj.io/uplink/private/metaclient", "WithRetry", "retried request"},
+		{"storj.io/uplink/private/metaclient", "(*Client).BeginSegment", "finished segment operation"},
+		{"storj.io/uplink/private/storage/streams/segmentupload", "segment-upload", "finished segment operation"},
+		{"storj.io/uplink/private/metaclient", "(*Client).GetProjectInfo", ""},
+		{"storj.io/uplink-c", "uplink_download_segment", ""},
+	} {
+		message, ok := internalEventMessage(monkit.ScopeNamed(tc.scope).FuncNamed(tc.name))
+		require.Equal(t, tc.message != "", ok, tc)
+		require.Equal(t, tc.message, message, tc)
+	}
+}
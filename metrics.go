@@ -0,0 +1,74 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+import (
+	"encoding/json"
+	"math"
+	"unsafe"
+
+	"github.com/spacemonkeygo/monkit/v3"
+)
+
+// metric is a single statistic reported by monkit.
+type metric struct {
+	Series string  `json:"series"`
+	Field  string  `json:"field"`
+	Value  float64 `json:"value"`
+}
+
+// collectMetrics returns all statistics of the default registry.
+//
+// Values that are not finite are skipped, since they cannot be represented
+// in JSON.
+func collectMetrics() []metric {
+	metrics := []metric{}
+	monkit.Default.Stats(func(key monkit.SeriesKey, field string, value float64) {
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			return
+		}
+		metrics = append(metrics, metric{
+			Series: key.String(),
+			Field:  field,
+			Value:  value,
+		})
+	})
+	return metrics
+}
+
+// uplink_metrics_json returns all collected metrics as a JSON array of
+// {"series", "field", "value"} objects.
+//
+//export uplink_metrics_json
+func uplink_metrics_json() C.UplinkStringResult {
+	data, err := json.Marshal(collectMetrics())
+	if err != nil {
+		return C.UplinkStringResult{
+			error: mallocError(err),
+		}
+	}
+
+	return C.UplinkStringResult{
+		string: cstring(string(data)),
+	}
+}
+
+// uplink_metrics_iterate calls callback for each collected metric.
+//
+//export uplink_metrics_iterate
+func uplink_metrics_iterate(callback C.UplinkMetricCallback, user_data unsafe.Pointer) *C.UplinkError {
+	if callback == nil {
+		return mallocError(ErrNull.New("callback"))
+	}
+
+	for _, m := range collectMetrics() {
+		series, field := cstring(m.Series), cstring(m.Field)
+		callMetricCallback(callback, series, field, C.double(m.Value), user_data)
+		free(unsafe.Pointer(series))
+		free(unsafe.Pointer(field))
+	}
+	return nil
+}
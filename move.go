@@ -32,9 +32,13 @@ func uplink_move_object(project *C.UplinkProject, old_bucket_name, old_object_ke
 		return mallocError(ErrInvalidHandle.New("project"))
 	}
 
-	err := proj.MoveObject(proj.scope.ctx,
+	ctx := proj.scope.ctx
+	span := proj.startSpan(&ctx, "uplink_move_object", C.GoString(old_bucket_name), C.GoString(old_object_key))
+	err := proj.MoveObject(ctx,
 		C.GoString(old_bucket_name), C.GoString(old_object_key),
 		C.GoString(new_bucket_name), C.GoString(new_object_key),
 		nil)
+	span.finish(err)
+	proj.stats.record(C.UPLINK_OPERATION_MOVE_OBJECT, err)
 	return mallocError(err)
 }
@@ -0,0 +1,36 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+import "context"
+
+// uplink_move_prefix moves all objects under old_prefix in old_bucket_name
+// to new_prefix in new_bucket_name.
+//
+// The objects are listed recursively and moved in batches with at most
+// options.concurrency moves in parallel. The batch in which an object fails
+// is finished, after which the moving stops. The report counts the listed,
+// moved and failed objects and has a cursor to resume moving from.
+//
+// old_prefix, new_prefix: must end with "/", or be empty for the whole
+// bucket. new_prefix must not be under old_prefix in the same bucket.
+//
+//export uplink_move_prefix
+func uplink_move_prefix(project *C.UplinkProject, old_bucket_name, old_prefix, new_bucket_name, new_prefix *C.uplink_const_char,
+	options *C.UplinkMovePrefixOptions) C.UplinkPrefixReportResult {
+	var concurrency C.int32_t
+	var cursor *C.uplink_const_char
+	if options != nil {
+		concurrency, cursor = options.concurrency, options.cursor
+	}
+
+	return transferPrefix(project, "uplink_move_prefix", old_bucket_name, old_prefix, new_bucket_name, new_prefix, concurrency, cursor,
+		func(ctx context.Context, proj *Project, oldBucket, oldKey, newBucket, newKey string) error {
+			err := proj.MoveObject(ctx, oldBucket, oldKey, newBucket, newKey, nil)
+			proj.stats.record(C.UPLINK_OPERATION_MOVE_OBJECT, err)
+			return err
+		})
+}
@@ -46,7 +46,11 @@ func uplink_begin_upload(project *C.UplinkProject, bucket_name, object_key *C.up
 		}
 	}
 
-	info, err := proj.BeginUpload(proj.scope.ctx, C.GoString(bucket_name), C.GoString(object_key), opts)
+	ctx := proj.scope.ctx
+	span := proj.startSpan(&ctx, "uplink_begin_upload", C.GoString(bucket_name), C.GoString(object_key))
+	info, err := proj.BeginUpload(ctx, C.GoString(bucket_name), C.GoString(object_key), opts)
+	span.finish(err)
+	proj.stats.record(C.UPLINK_OPERATION_BEGIN_UPLOAD, err)
 	return C.UplinkUploadInfoResult{
 		error: mallocError(err),
 		info:  mallocUploadInfo(&info),
@@ -68,8 +72,8 @@ func uploadToC(info *uplink.UploadInfo) C.UplinkUploadInfo {
 		return C.UplinkUploadInfo{}
 	}
 	return C.UplinkUploadInfo{
-		upload_id: C.CString(info.UploadID),
-		key:       C.CString(info.Key),
+		upload_id: cstring(info.UploadID),
+		key:       cstring(info.Key),
 		is_prefix: C.bool(info.IsPrefix),
 		system: C.UplinkSystemMetadata{
 			created:        timeToUnix(info.System.Created),
@@ -95,14 +99,14 @@ func uplink_free_upload_info(info *C.UplinkUploadInfo) {
 	if info == nil {
 		return
 	}
-	defer C.free(unsafe.Pointer(info))
+	defer free(unsafe.Pointer(info))
 
 	if info.upload_id != nil {
-		C.free(unsafe.Pointer(info.upload_id))
+		free(unsafe.Pointer(info.upload_id))
 		info.upload_id = nil
 	}
 	if info.key != nil {
-		C.free(unsafe.Pointer(info.key))
+		free(unsafe.Pointer(info.key))
 		info.key = nil
 	}
 
@@ -146,7 +150,11 @@ func uplink_commit_upload(project *C.UplinkProject, bucket_name, object_key, upl
 		opts.CustomMetadata = customMetadataFromC(options.custom_metadata)
 	}
 
-	object, err := proj.CommitUpload(proj.scope.ctx, C.GoString(bucket_name), C.GoString(object_key), C.GoString(upload_id), opts)
+	ctx := proj.scope.ctx
+	span := proj.startSpan(&ctx, "uplink_commit_upload", C.GoString(bucket_name), C.GoString(object_key))
+	object, err := proj.CommitUpload(ctx, C.GoString(bucket_name), C.GoString(object_key), C.GoString(upload_id), opts)
+	span.finish(err)
+	proj.stats.record(C.UPLINK_OPERATION_COMMIT_UPLOAD, err)
 	return C.UplinkCommitUploadResult{
 		error:  mallocError(err),
 		object: mallocObject(object),
@@ -183,7 +191,11 @@ func uplink_abort_upload(project *C.UplinkProject, bucket_name, object_key, uplo
 		return mallocError(ErrInvalidHandle.New("project"))
 	}
 
-	err := proj.AbortUpload(proj.scope.ctx, C.GoString(bucket_name), C.GoString(object_key), C.GoString(upload_id))
+	ctx := proj.scope.ctx
+	span := proj.startSpan(&ctx, "uplink_abort_upload", C.GoString(bucket_name), C.GoString(object_key))
+	err := proj.AbortUpload(ctx, C.GoString(bucket_name), C.GoString(object_key), C.GoString(upload_id))
+	span.finish(err)
+	proj.stats.record(C.UPLINK_OPERATION_ABORT_UPLOAD, err)
 	return mallocError(err)
 }
 
@@ -191,6 +203,9 @@ func uplink_abort_upload(project *C.UplinkProject, bucket_name, object_key, uplo
 type PartUpload struct {
 	scope
 	partUpload *uplink.PartUpload
+
+	stats    *projectStats
+	progress inProgress
 }
 
 // uplink_upload_part starts an part upload to the specified key nad part number.
@@ -226,14 +241,21 @@ func uplink_upload_part(project *C.UplinkProject, bucket_name, object_key, uploa
 	}
 
 	scope := proj.scope.child()
+	span := proj.startSpan(&scope.ctx, "uplink_upload_part", C.GoString(bucket_name), C.GoString(object_key))
 	partUpload, err := proj.UploadPart(scope.ctx, C.GoString(bucket_name), C.GoString(object_key), C.GoString(upload_id), uint32(part_number))
+	span.finish(err)
+	proj.stats.record(C.UPLINK_OPERATION_UPLOAD_PART, err)
 	if err != nil {
 		return C.UplinkPartUploadResult{
 			error: mallocError(err),
 		}
 	}
+
+	up := &PartUpload{scope: scope, partUpload: partUpload, stats: proj.stats}
+	up.progress.start(&proj.stats.uploads)
+
 	return C.UplinkPartUploadResult{
-		part_upload: (*C.UplinkPartUpload)(mallocHandle(universe.Add(&PartUpload{scope, partUpload}))),
+		part_upload: (*C.UplinkPartUpload)(mallocHandle(universe.Add(up))),
 	}
 }
 
@@ -270,7 +292,13 @@ func uplink_part_upload_write(upload *C.UplinkPartUpload, bytes unsafe.Pointer,
 	}
 
 	buf := unsafe.Slice((*byte)(bytes), ilength)
+	ctx := up.scope.ctx
+	span := startSpan(&ctx, nil, "uplink_part_upload_write", "", "")
 	n, err := up.partUpload.Write(buf)
+	span.addBytes(n)
+	span.finish(err)
+	up.stats.bytesUploaded.Add(int64(n))
+	up.stats.recordError(err)
 	return C.UplinkWriteResult{
 		bytes_written: C.size_t(n),
 		error:         mallocError(err),
@@ -290,7 +318,12 @@ func uplink_part_upload_commit(upload *C.UplinkPartUpload) *C.UplinkError {
 		return mallocError(ErrInvalidHandle.New("part upload"))
 	}
 
+	ctx := up.scope.ctx
+	span := startSpan(&ctx, nil, "uplink_part_upload_commit", "", "")
 	err := up.partUpload.Commit()
+	span.finish(err)
+	up.progress.finish()
+	up.stats.recordError(err)
 	return mallocError(err)
 }
 
@@ -307,7 +340,12 @@ func uplink_part_upload_abort(upload *C.UplinkPartUpload) *C.UplinkError {
 		return mallocError(ErrInvalidHandle.New("part upload"))
 	}
 
+	ctx := up.scope.ctx
+	span := startSpan(&ctx, nil, "uplink_part_upload_abort", "", "")
 	err := up.partUpload.Abort()
+	span.finish(err)
+	up.progress.finish()
+	up.stats.recordError(err)
 	return mallocError(err)
 }
 
@@ -369,7 +407,7 @@ func partToC(part *uplink.Part) C.UplinkPart {
 		part_number: C.uint32_t(part.PartNumber),
 		size:        C.size_t(part.Size),
 		modified:    timeToUnix(part.Modified),
-		etag:        C.CString(string(part.ETag)),
+		etag:        cstring(string(part.ETag)),
 		etag_length: C.size_t(len(part.ETag)),
 	}
 }
@@ -394,11 +432,12 @@ func freePartUpload(partUpload *C.UplinkPartUpload) {
 	if partUpload == nil {
 		return
 	}
-	defer C.free(unsafe.Pointer(partUpload))
+	defer free(unsafe.Pointer(partUpload))
 	defer universe.Del(partUpload._handle)
 
 	up, ok := universe.Get(partUpload._handle).(*PartUpload)
 	if ok {
+		up.progress.finish()
 		up.cancel()
 	}
 }
@@ -410,10 +449,10 @@ func uplink_free_part(part *C.UplinkPart) {
 	if part == nil {
 		return
 	}
-	defer C.free(unsafe.Pointer(part))
+	defer free(unsafe.Pointer(part))
 
 	if part.etag != nil {
-		C.free(unsafe.Pointer(part.etag))
+		free(unsafe.Pointer(part.etag))
 		part.etag = nil
 		part.etag_length = 0
 	}
@@ -459,7 +498,10 @@ func uplink_list_uploads(project *C.UplinkProject, bucket_name *C.uplink_const_c
 	}
 
 	scope := proj.scope.child()
+	span := proj.startSpan(&scope.ctx, "uplink_list_uploads", C.GoString(bucket_name), "")
 	iterator := proj.ListUploads(scope.ctx, C.GoString(bucket_name), opts)
+	span.finish(nil)
+	proj.stats.record(C.UPLINK_OPERATION_LIST_UPLOADS, nil)
 
 	return (*C.UplinkUploadIterator)(mallocHandle(universe.Add(&UploadIterator{
 		scope:    scope,
@@ -533,7 +575,7 @@ func uplink_free_upload_iterator(iterator *C.UplinkUploadIterator) {
 	if iterator == nil {
 		return
 	}
-	defer C.free(unsafe.Pointer(iterator))
+	defer free(unsafe.Pointer(iterator))
 	defer universe.Del(iterator._handle)
 
 	iter, ok := universe.Get(iterator._handle).(*UploadIterator)
@@ -590,7 +632,10 @@ func uplink_list_upload_parts(project *C.UplinkProject, bucket_name, object_key,
 	}
 
 	scope := proj.scope.child()
+	span := proj.startSpan(&scope.ctx, "uplink_list_upload_parts", C.GoString(bucket_name), C.GoString(object_key))
 	iterator := proj.ListUploadParts(scope.ctx, C.GoString(bucket_name), C.GoString(object_key), C.GoString(upload_id), opts)
+	span.finish(nil)
+	proj.stats.record(C.UPLINK_OPERATION_LIST_UPLOAD_PARTS, nil)
 
 	return (*C.UplinkPartIterator)(mallocHandle(universe.Add(&PartIterator{
 		scope:    scope,
@@ -664,7 +709,7 @@ func uplink_free_part_iterator(iterator *C.UplinkPartIterator) {
 	if iterator == nil {
 		return
 	}
-	defer C.free(unsafe.Pointer(iterator))
+	defer free(unsafe.Pointer(iterator))
 	defer universe.Del(iterator._handle)
 
 	iter, ok := universe.Get(iterator._handle).(*PartIterator)
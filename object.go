@@ -38,7 +38,11 @@ func uplink_stat_object(project *C.UplinkProject, bucket_name, object_key *C.upl
 		}
 	}
 
-	object, err := proj.StatObject(proj.scope.ctx, C.GoString(bucket_name), C.GoString(object_key))
+	ctx := proj.scope.ctx
+	span := proj.startSpan(&ctx, "uplink_stat_object", C.GoString(bucket_name), C.GoString(object_key))
+	object, err := proj.StatObject(ctx, C.GoString(bucket_name), C.GoString(object_key))
+	span.finish(err)
+	proj.stats.record(C.UPLINK_OPERATION_STAT_OBJECT, err)
 	return C.UplinkObjectResult{
 		error:  mallocError(err),
 		object: mallocObject(object),
@@ -72,7 +76,11 @@ func uplink_delete_object(project *C.UplinkProject, bucket_name, object_key *C.u
 		}
 	}
 
-	deleted, err := proj.DeleteObject(proj.scope.ctx, C.GoString(bucket_name), C.GoString(object_key))
+	ctx := proj.scope.ctx
+	span := proj.startSpan(&ctx, "uplink_delete_object", C.GoString(bucket_name), C.GoString(object_key))
+	deleted, err := proj.DeleteObject(ctx, C.GoString(bucket_name), C.GoString(object_key))
+	span.finish(err)
+	proj.stats.record(C.UPLINK_OPERATION_DELETE_OBJECT, err)
 	return C.UplinkObjectResult{
 		error:  mallocError(err),
 		object: mallocObject(deleted),
@@ -94,7 +102,7 @@ func objectToC(object *uplink.Object) C.UplinkObject {
 		return C.UplinkObject{}
 	}
 	return C.UplinkObject{
-		key:       C.CString(object.Key),
+		key:       cstring(object.Key),
 		is_prefix: C.bool(object.IsPrefix),
 		system: C.UplinkSystemMetadata{
 			created:        timeToUnix(object.System.Created),
@@ -120,10 +128,10 @@ func uplink_free_object(obj *C.UplinkObject) {
 	if obj == nil {
 		return
 	}
-	defer C.free(unsafe.Pointer(obj))
+	defer free(unsafe.Pointer(obj))
 
 	if obj.key != nil {
-		C.free(unsafe.Pointer(obj.key))
+		free(unsafe.Pointer(obj.key))
 		obj.key = nil
 	}
 
@@ -153,7 +161,11 @@ func uplink_update_object_metadata(project *C.UplinkProject, bucket_name, object
 		return mallocError(ErrInvalidHandle.New("project"))
 	}
 
-	err := proj.UpdateObjectMetadata(proj.scope.ctx, C.GoString(bucket_name), C.GoString(object_key), customMetadataFromC(new_metadata), nil)
+	ctx := proj.scope.ctx
+	span := proj.startSpan(&ctx, "uplink_update_object_metadata", C.GoString(bucket_name), C.GoString(object_key))
+	err := proj.UpdateObjectMetadata(ctx, C.GoString(bucket_name), C.GoString(object_key), customMetadataFromC(new_metadata), nil)
+	span.finish(err)
+	proj.stats.record(C.UPLINK_OPERATION_UPDATE_OBJECT_METADATA, err)
 	return mallocError(err)
 }
 
@@ -51,7 +51,10 @@ func uplink_list_objects(project *C.UplinkProject, bucket_name *C.uplink_const_c
 	}
 
 	scope := proj.scope.child()
+	span := proj.startSpan(&scope.ctx, "uplink_list_objects", C.GoString(bucket_name), "")
 	iterator := proj.ListObjects(scope.ctx, C.GoString(bucket_name), opts)
+	span.finish(nil)
+	proj.stats.record(C.UPLINK_OPERATION_LIST_OBJECTS, nil)
 
 	return (*C.UplinkObjectIterator)(mallocHandle(universe.Add(&ObjectIterator{
 		scope:    scope,
@@ -125,7 +128,7 @@ func uplink_free_object_iterator(iterator *C.UplinkObjectIterator) {
 	if iterator == nil {
 		return
 	}
-	defer C.free(unsafe.Pointer(iterator))
+	defer free(unsafe.Pointer(iterator))
 	defer universe.Del(iterator._handle)
 
 	iter, ok := universe.Get(iterator._handle).(*ObjectIterator)
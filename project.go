@@ -6,6 +6,7 @@ package main
 // #include "uplink_definitions.h"
 import "C"
 import (
+	"sync/atomic"
 	"unsafe"
 
 	"storj.io/uplink"
@@ -15,6 +16,9 @@ import (
 type Project struct {
 	scope
 	*uplink.Project
+
+	stats       *projectStats
+	traceParent atomic.Pointer[traceParent]
 }
 
 // uplink_open_project opens project using access grant.
@@ -37,7 +41,11 @@ func uplink_open_project(access *C.UplinkAccess) C.UplinkProjectResult {
 	scope := rootScope("")
 	config := uplink.Config{}
 
-	proj, err := config.OpenProject(scope.ctx, acc.Access)
+	logDebug("opening project", "satellite", acc.SatelliteAddress())
+	ctx := scope.ctx
+	span := startSpan(&ctx, nil, "uplink_open_project", "", "")
+	proj, err := config.OpenProject(ctx, acc.Access)
+	span.finish(err)
 	if err != nil {
 		scope.cancel()
 		return C.UplinkProjectResult{
@@ -46,7 +54,7 @@ func uplink_open_project(access *C.UplinkAccess) C.UplinkProjectResult {
 	}
 
 	return C.UplinkProjectResult{
-		project: (*C.UplinkProject)(mallocHandle(universe.Add(&Project{scope, proj}))),
+		project: (*C.UplinkProject)(mallocHandle(universe.Add(&Project{scope: scope, Project: proj, stats: &projectStats{}}))),
 	}
 }
 
@@ -63,6 +71,7 @@ func uplink_close_project(project *C.UplinkProject) *C.UplinkError {
 		return mallocError(ErrInvalidHandle.New("project"))
 	}
 
+	logDebug("closing project")
 	proj.cancel()
 	return mallocError(proj.Close())
 }
@@ -91,7 +100,11 @@ func uplink_revoke_access(project *C.UplinkProject, access *C.UplinkAccess) *C.U
 
 	scope := rootScope("")
 
-	return mallocError(proj.RevokeAccess(scope.ctx, acc.Access))
+	span := proj.startSpan(&scope.ctx, "uplink_revoke_access", "", "")
+	err := proj.RevokeAccess(scope.ctx, acc.Access)
+	span.finish(err)
+	proj.stats.record(C.UPLINK_OPERATION_REVOKE_ACCESS, err)
+	return mallocError(err)
 }
 
 // uplink_free_project_result frees any associated resources.
@@ -107,7 +120,7 @@ func freeProject(project *C.UplinkProject) {
 	if project == nil {
 		return
 	}
-	defer C.free(unsafe.Pointer(project))
+	defer free(unsafe.Pointer(project))
 	defer universe.Del(project._handle)
 
 	proj, ok := universe.Get(project._handle).(*Project)
@@ -117,6 +130,7 @@ func freeProject(project *C.UplinkProject) {
 
 	proj.cancel()
 	// in case we haven't already closed the project
-	_ = proj.Close()
-	// TODO: log error when we didn't close manually and the close returns an error
+	if err := proj.Close(); err != nil {
+		logError("closing project on free failed", "error", err)
+	}
 }
@@ -0,0 +1,144 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+import (
+	"context"
+	"unsafe"
+
+	"storj.io/uplink"
+)
+
+// uplink_revoke_serialized_access revokes the API key embedded in the
+// serialized access grant.
+//
+//export uplink_revoke_serialized_access
+func uplink_revoke_serialized_access(project *C.UplinkProject, access *C.uplink_const_char) *C.UplinkError {
+	if project == nil {
+		return mallocError(ErrNull.New("project"))
+	}
+	if access == nil {
+		return mallocError(ErrNull.New("access"))
+	}
+
+	proj, ok := universe.Get(project._handle).(*Project)
+	if !ok {
+		return mallocError(ErrInvalidHandle.New("project"))
+	}
+
+	scope := rootScope("")
+
+	span := proj.startSpan(&scope.ctx, "uplink_revoke_serialized_access", "", "")
+	err := proj.revokeSerializedAccess(scope.ctx, C.GoString(access))
+	span.finish(err)
+	return mallocError(err)
+}
+
+// uplink_revoke_accesses revokes the API keys embedded in the serialized
+// access grants.
+//
+// The access grants are revoked in parallel, a failure doesn't stop the
+// others from being revoked. The result has an error for every access grant in the same order,
+// which is NULL when the access grant was revoked.
+//
+//export uplink_revoke_accesses
+func uplink_revoke_accesses(project *C.UplinkProject, accesses **C.uplink_const_char, accesses_count C.size_t) C.UplinkRevokeAccessesResult { //nolint:golint
+	if project == nil {
+		return C.UplinkRevokeAccessesResult{
+			error: mallocError(ErrNull.New("project")),
+		}
+	}
+	if accesses == nil && accesses_count > 0 {
+		return C.UplinkRevokeAccessesResult{
+			error: mallocError(ErrNull.New("accesses")),
+		}
+	}
+
+	proj, ok := universe.Get(project._handle).(*Project)
+	if !ok {
+		return C.UplinkRevokeAccessesResult{
+			error: mallocError(ErrInvalidHandle.New("project")),
+		}
+	}
+
+	count, ok := safeConvertToInt(accesses_count)
+	if !ok {
+		return C.UplinkRevokeAccessesResult{
+			error: mallocError(ErrInvalidArg.New("accesses_count too large")),
+		}
+	}
+
+	serialized := make([]*string, count)
+	if count > 0 {
+		for i, access := range unsafe.Slice(accesses, count) {
+			if access != nil {
+				s := C.GoString(access)
+				serialized[i] = &s
+			}
+		}
+	}
+
+	scope := rootScope("")
+
+	span := proj.startSpan(&scope.ctx, "uplink_revoke_accesses", "", "")
+	errs := revokeAccesses(scope.ctx, proj.revokeSerializedAccess, serialized)
+	span.finish(nil)
+
+	cerrors := (**C.UplinkError)(calloc(C.size_t(len(errs)), C.size_t(unsafe.Sizeof((*C.UplinkError)(nil)))))
+	array := unsafe.Slice(cerrors, len(errs))
+	failed := 0
+	for i, err := range errs {
+		if err != nil {
+			array[i] = mallocError(err)
+			failed++
+		}
+	}
+
+	return C.UplinkRevokeAccessesResult{
+		errors:       cerrors,
+		errors_count: C.size_t(len(errs)),
+		failed_count: C.size_t(failed),
+	}
+}
+
+// uplink_free_revoke_accesses_result frees the resources associated with revoke accesses result.
+//
+//export uplink_free_revoke_accesses_result
+func uplink_free_revoke_accesses_result(result C.UplinkRevokeAccessesResult) {
+	uplink_free_error(result.error)
+	if result.errors == nil {
+		return
+	}
+	defer free(unsafe.Pointer(result.errors))
+
+	for _, err := range unsafe.Slice(result.errors, int(result.errors_count)) {
+		uplink_free_error(err)
+	}
+}
+
+// revokeSerializedAccess parses and revokes the access grant.
+func (proj *Project) revokeSerializedAccess(ctx context.Context, serialized string) error {
+	access, err := uplink.ParseAccess(serialized)
+	if err != nil {
+		return err
+	}
+
+	err = proj.RevokeAccess(ctx, access)
+	proj.stats.record(C.UPLINK_OPERATION_REVOKE_ACCESS, err)
+	return err
+}
+
+// revokeAccesses revokes every serialized access grant with at most
+// defaultConcurrency revokes in parallel and returns the error for each of
+// them. A nil entry is a NULL access grant.
+func revokeAccesses(ctx context.Context, revoke func(context.Context, string) error, serialized []*string) []error {
+	return parallel(ctx, len(serialized), defaultConcurrency, func(ctx context.Context, i int) error {
+		if serialized[i] == nil {
+			return ErrNull.New("accesses[%d]", i)
+		}
+		return revoke(ctx, *serialized[i])
+	})
+}
@@ -0,0 +1,43 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRevokeAccesses(t *testing.T) {
+	first, second := "first", "second"
+
+	var mu sync.Mutex
+	var revoked []string
+	revoke := func(ctx context.Context, access string) error {
+		if access == second {
+			return errors.New("revoke failed")
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		revoked = append(revoked, access)
+		return nil
+	}
+
+	errs := revokeAccesses(context.Background(), revoke, []*string{&first, nil, &second, &first})
+	require.Len(t, errs, 4)
+	require.NoError(t, errs[0])
+	require.True(t, ErrNull.Has(errs[1]))
+	require.EqualError(t, errs[2], "revoke failed")
+	require.NoError(t, errs[3])
+	require.Equal(t, []string{first, first}, revoked)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	errs = revokeAccesses(ctx, revoke, []*string{&first})
+	require.ErrorIs(t, errs[0], context.Canceled)
+	require.Len(t, revoked, 2)
+}
@@ -0,0 +1,90 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zeebo/errs"
+
+	"storj.io/uplink"
+)
+
+// uplink_shutdown closes every live handle in the library.
+//
+// Pending uploads are aborted, downloads and projects are closed, and the
+// scopes of all iterators are canceled. Handles are released newest first,
+// so transfers are closed before the project they belong to.
+//
+// Any handle that was live before the call becomes invalid. Results still
+// need to be released with the matching uplink_free_* function, which then
+// only frees the C memory.
+//
+// The returned error combines all the errors that happened while closing.
+//
+//export uplink_shutdown
+func uplink_shutdown() *C.UplinkError {
+	values := universe.DelAll()
+	logInfo("shutting down", "handles", len(values))
+
+	var group errs.Group
+	for _, value := range values {
+		if err := shutdownValue(value); err != nil {
+			logWarn("closing handle on shutdown failed", "type", fmt.Sprintf("%T", value), "error", err)
+			group.Add(err)
+		}
+	}
+	return mallocError(group.Err())
+}
+
+// shutdownValue closes a single value from the universe.
+func shutdownValue(value interface{}) error {
+	switch v := value.(type) {
+	case *Project:
+		v.cancel()
+		return v.Close()
+	case *Upload:
+		err := v.upload.Abort()
+		v.cancel()
+		return ignoreUploadDone(err)
+	case *PartUpload:
+		err := v.partUpload.Abort()
+		v.cancel()
+		return ignoreUploadDone(err)
+	case *Download:
+		var err error
+		if !v.closed {
+			err = v.download.Close()
+		}
+		v.cancel()
+		return err
+	case *ObjectIterator:
+		cancelScope(v.scope)
+	case *BucketIterator:
+		cancelScope(v.scope)
+	case *UploadIterator:
+		cancelScope(v.scope)
+	case *PartIterator:
+		cancelScope(v.scope)
+	}
+	return nil
+}
+
+// ignoreUploadDone ignores the error of aborting an already finished upload.
+func ignoreUploadDone(err error) error {
+	if errors.Is(err, uplink.ErrUploadDone) {
+		return nil
+	}
+	return err
+}
+
+// cancelScope cancels scope, when it was initialized.
+func cancelScope(scope scope) {
+	if scope.cancel != nil {
+		scope.cancel()
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+import (
+	"sync/atomic"
+	"unsafe"
+)
+
+// projectStats contains cumulative statistics of a project.
+type projectStats struct {
+	bytesUploaded   atomic.Int64
+	bytesDownloaded atomic.Int64
+
+	uploads   atomic.Int64
+	downloads atomic.Int64
+
+	operations [C.UPLINK_OPERATION_COUNT]atomic.Int64
+	errors     [C.UPLINK_ERROR_CODE_LIMIT]atomic.Int64
+}
+
+// record counts the operation and the error it returned.
+func (stats *projectStats) record(operation C.int, err error) {
+	stats.operations[operation].Add(1)
+	stats.recordError(err)
+}
+
+// recordError counts err by its error code, EOF is not counted.
+func (stats *projectStats) recordError(err error) {
+	if err == nil {
+		return
+	}
+	code := errorCode(err)
+	if code < 0 || code >= C.UPLINK_ERROR_CODE_LIMIT {
+		return
+	}
+	stats.errors[code].Add(1)
+}
+
+// inProgress tracks a single upload or download until it finishes.
+type inProgress struct {
+	counter *atomic.Int64
+	done    atomic.Bool
+}
+
+// start increments counter and tracks the transfer until finish is called.
+func (transfer *inProgress) start(counter *atomic.Int64) {
+	transfer.counter = counter
+	counter.Add(1)
+}
+
+// finish marks the transfer as done, only the first call has an effect.
+func (transfer *inProgress) finish() {
+	if transfer.counter != nil && transfer.done.CompareAndSwap(false, true) {
+		transfer.counter.Add(-1)
+	}
+}
+
+// uplink_project_stats returns the statistics of the project since it was opened.
+//
+//export uplink_project_stats
+func uplink_project_stats(project *C.UplinkProject) C.UplinkProjectStatsResult {
+	if project == nil {
+		return C.UplinkProjectStatsResult{
+			error: mallocError(ErrNull.New("project")),
+		}
+	}
+
+	proj, ok := universe.Get(project._handle).(*Project)
+	if !ok {
+		return C.UplinkProjectStatsResult{
+			error: mallocError(ErrInvalidHandle.New("project")),
+		}
+	}
+
+	stats := (*C.UplinkProjectStats)(calloc(1, C.sizeof_UplinkProjectStats))
+	stats.bytes_uploaded = C.int64_t(proj.stats.bytesUploaded.Load())
+	stats.bytes_downloaded = C.int64_t(proj.stats.bytesDownloaded.Load())
+	stats.uploads_in_progress = C.int64_t(proj.stats.uploads.Load())
+	stats.downloads_in_progress = C.int64_t(proj.stats.downloads.Load())
+	stats.operations, stats.operations_count = mallocCounters(proj.stats.operations[:])
+	stats.errors, stats.errors_count = mallocCounters(proj.stats.errors[:])
+
+	return C.UplinkProjectStatsResult{
+		stats: stats,
+	}
+}
+
+// uplink_free_project_stats_result frees any resources associated with project stats result.
+//
+//export uplink_free_project_stats_result
+func uplink_free_project_stats_result(result C.UplinkProjectStatsResult) {
+	uplink_free_error(result.error)
+	if result.stats == nil {
+		return
+	}
+	defer free(unsafe.Pointer(result.stats))
+
+	free(unsafe.Pointer(result.stats.operations))
+	free(unsafe.Pointer(result.stats.errors))
+}
+
+// mallocCounters copies the values of counters to a C array.
+func mallocCounters(counters []atomic.Int64) (*C.int64_t, C.size_t) {
+	array := (*C.int64_t)(calloc(C.size_t(len(counters)), C.sizeof_int64_t))
+	values := unsafe.Slice(array, len(counters))
+	for i := range counters {
+		values[i] = C.int64_t(counters[i].Load())
+	}
+	return array, C.size_t(len(counters))
+}
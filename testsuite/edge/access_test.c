@@ -34,6 +34,7 @@ int main(void)
 
         EdgeCredentialsResult credentials_result = edge_register_access(config, access, NULL);
         require_noerror(credentials_result.error);
+        require(credentials_result.access != NULL);
 
         EdgeCredentials credentials = *credentials_result.credentials;
         require(strcmp("l5pucy3dmvzxgs3fpfewix27l5pq", credentials.access_key_id) == 0);
@@ -43,6 +44,47 @@ int main(void)
         edge_free_credentials_result(credentials_result);
     }
 
+    {
+        // Expiry
+        EdgeConfig config = {
+            .auth_service_address = auth_service_unencrypted_addr,
+            .insecure_unencrypted_connection = insecure_skip_verify,
+        };
+
+        EdgeRegisterAccessOptions options = {
+            .is_public = true,
+            .expires = 2000000000,
+        };
+
+        EdgeCredentialsResult credentials_result = edge_register_access(config, access, &options);
+        require_noerror(credentials_result.error);
+        require(credentials_result.credentials->expires == 2000000000);
+        require(credentials_result.credentials->is_public);
+        edge_free_credentials_result(credentials_result);
+
+        options.expires = 1000000000;
+        credentials_result = edge_register_access(config, access, &options);
+        require_error(credentials_result.error, UPLINK_ERROR_INTERNAL);
+        require(credentials_result.access == NULL);
+        edge_free_credentials_result(credentials_result);
+    }
+
+    {
+        // Share manifest without a project
+        EdgeConfig config = {
+            .auth_service_address = auth_service_unencrypted_addr,
+            .insecure_unencrypted_connection = insecure_skip_verify,
+        };
+
+        EdgeShareManifestResult manifest_result =
+            edge_share_manifest(config, NULL, access, "https://link.example", "mybucket", "myprefix/", NULL);
+        require_error(manifest_result.error, UPLINK_ERROR_INTERNAL);
+        require(manifest_result.manifest == NULL);
+        require(manifest_result.credentials == NULL);
+        require(manifest_result.access == NULL);
+        edge_free_share_manifest_result(manifest_result);
+    }
+
     {
         // TLS certificate error
         EdgeConfig config = {
@@ -0,0 +1,122 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+#include <stdio.h>
+#include <string.h>
+
+#include "../require.h"
+#define UPLINK_DISABLE_NAMESPACE_COMPAT
+#include "uplink.h"
+
+const char *minimal_access =
+    "13J4Upun87ATb3T5T5sDXVeQaCzWFZeF9Ly4ELfxS5hUwTL8APEkwahTEJ1wxZjyErimiDs3kgid33kDLuYPYtwaY7Toy32mCTapfrUB814X13RiA8"
+    "44HPWK3QLKZb9cAoVceTowmNZXWbcUMKNbkMHCURE4hn8ZrdHPE3S86yngjvDxwKmarfGx";
+
+int main(void)
+{
+    // uses the in-process auth service, which doesn't need network access
+    UplinkStringResult address_result = edge_internal_StartAuthService("https://gateway.example");
+    require_noerror(address_result.error);
+    const char *address = address_result.string;
+
+    UplinkAccessResult access_result = uplink_parse_access(minimal_access);
+    require_noerror(access_result.error);
+    UplinkAccess *access = access_result.access;
+
+    EdgeConfig config = {
+        .auth_service_address = address,
+        .insecure_unencrypted_connection = true,
+    };
+
+    EdgeRegisterAccessOptions options = {
+        .is_public = true,
+        .expires = 2000000000,
+    };
+
+    EdgeCredentialsResult credentials_result = edge_register_access(config, access, &options);
+    require_noerror(credentials_result.error);
+    EdgeCredentials *credentials = credentials_result.credentials;
+    require(strlen(credentials->access_key_id) == 28);
+    require(strcmp("https://gateway.example", credentials->endpoint) == 0);
+    require(credentials->expires == 2000000000);
+
+    {
+        // the registered access grant expires
+        UplinkStringResult registered = edge_internal_AuthServiceAccess(address, credentials->access_key_id);
+        require_noerror(registered.error);
+
+        UplinkAccessResult registered_access = uplink_parse_access(registered.string);
+        require_noerror(registered_access.error);
+
+        UplinkTimeResult expires_result = uplink_access_expires_at(registered_access.access);
+        require_noerror(expires_result.error);
+        require(expires_result.time == 2000000000);
+        uplink_free_time_result(expires_result);
+
+        uplink_free_access_result(registered_access);
+        uplink_free_string_result(registered);
+    }
+
+    {
+        UplinkStringResult registered = edge_internal_AuthServiceAccess(address, "missing");
+        require_error(registered.error, UPLINK_ERROR_INTERNAL);
+        uplink_free_string_result(registered);
+    }
+
+    {
+        // the registered access is returned and differs from access
+        EdgeCredentialsResult unlimited_result = edge_register_access(config, access, NULL);
+        require_noerror(unlimited_result.error);
+        require(unlimited_result.credentials->expires == 0);
+        require(unlimited_result.access != NULL);
+
+        UplinkStringResult registered =
+            edge_internal_AuthServiceAccess(address, unlimited_result.credentials->access_key_id);
+        require_noerror(registered.error);
+        require(strcmp(unlimited_result.access, registered.string) == 0);
+
+        UplinkStringResult serialized = uplink_access_serialize(access);
+        require_noerror(serialized.error);
+        require(strcmp(serialized.string, registered.string) != 0);
+
+        uplink_free_string_result(serialized);
+        uplink_free_string_result(registered);
+        edge_free_credentials_result(unlimited_result);
+    }
+
+    {
+        UplinkStringResult url_result = edge_join_share_url("https://link.example", credentials->access_key_id,
+                                                            "mybucket", "mykey", NULL);
+        require_noerror(url_result.error);
+
+        EdgeShareURLResult parsed = edge_parse_share_url(url_result.string);
+        require_noerror(parsed.error);
+        require(strcmp(credentials->access_key_id, parsed.share_url->access_key_id) == 0);
+        edge_free_share_url_result(parsed);
+
+        uplink_free_string_result(url_result);
+    }
+
+    {
+        UplinkStringResult presigned = edge_presign_url(credentials, "GET", "mybucket", "mykey", 60, NULL, 0);
+        require_noerror(presigned.error);
+        require(strncmp("https://gateway.example/mybucket/mykey?", presigned.string, 39) == 0);
+        uplink_free_string_result(presigned);
+    }
+
+    edge_free_credentials_result(credentials_result);
+    uplink_free_access_result(access_result);
+
+    UplinkError *error = edge_internal_StopAuthService(address);
+    require_noerror(error);
+
+    error = edge_internal_StopAuthService(address);
+    require_error(error, UPLINK_ERROR_INTERNAL);
+    uplink_free_error(error);
+
+    uplink_free_string_result(address_result);
+
+    requiref(uplink_internal_UniverseIsEmpty(), "universe is not empty\n");
+
+    return 0;
+}
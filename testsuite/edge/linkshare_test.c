@@ -50,5 +50,41 @@ int main(void)
         uplink_free_string_result(result);
     }
 
+    {
+        // Parse
+        EdgeShareURLResult result = edge_parse_share_url(
+            "https://storjshare.example/raw/l5pucy3dmvzxgs3fpfewix27l5pq/mybucket/myprefix/my%20key");
+        require_noerror(result.error);
+
+        EdgeShareURL *share_url = result.share_url;
+        require(strcmp("https://storjshare.example", share_url->base_url) == 0);
+        require(strcmp("l5pucy3dmvzxgs3fpfewix27l5pq", share_url->access_key_id) == 0);
+        require(strcmp("mybucket", share_url->bucket) == 0);
+        require(strcmp("myprefix/my key", share_url->key) == 0);
+        require(share_url->raw);
+        require(!share_url->is_prefix);
+
+        edge_free_share_url_result(result);
+    }
+
+    {
+        // Parse prefix
+        EdgeShareURLResult result =
+            edge_parse_share_url("https://storjshare.example/s/l5pucy3dmvzxgs3fpfewix27l5pq/mybucket/myprefix/");
+        require_noerror(result.error);
+        require(strcmp("myprefix/", result.share_url->key) == 0);
+        require(!result.share_url->raw);
+        require(result.share_url->is_prefix);
+        edge_free_share_url_result(result);
+    }
+
+    {
+        // Error not a share URL
+        EdgeShareURLResult result = edge_parse_share_url("https://storjshare.example/mybucket/mykey");
+        require_error(result.error, UPLINK_ERROR_INTERNAL);
+        require(result.share_url == NULL);
+        edge_free_share_url_result(result);
+    }
+
     return 0;
 }
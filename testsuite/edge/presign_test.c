@@ -0,0 +1,76 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+#include <stdint.h>
+#include <stdio.h>
+#include <string.h>
+
+#include "../require.h"
+#define UPLINK_DISABLE_NAMESPACE_COMPAT
+#include "uplink.h"
+
+int main(void)
+{
+    EdgeCredentials credentials = {
+        .access_key_id = "l5pucy3dmvzxgs3fpfewix27l5pq",
+        .secret_key = "l5pvgzldojsxis3fpfpv6x27l5pv6x27l5pv6x27l5pv6",
+        .endpoint = "https://gateway.example",
+    };
+
+    {
+        // Happy
+        UplinkStringResult result = edge_presign_url(&credentials, "GET", "mybucket", "myprefix/my key", 3600, NULL, 0);
+        require_noerror(result.error);
+
+        const char *prefix = "https://gateway.example/mybucket/myprefix/my%20key?X-Amz-Algorithm=AWS4-HMAC-SHA256"
+                             "&X-Amz-Credential=l5pucy3dmvzxgs3fpfewix27l5pq%2F";
+        require(strncmp(prefix, result.string, strlen(prefix)) == 0);
+        require(strstr(result.string, "&X-Amz-Expires=3600&X-Amz-SignedHeaders=host&X-Amz-Signature=") != NULL);
+
+        uplink_free_string_result(result);
+    }
+
+    {
+        // Signed headers
+        EdgeHeader headers[] = {
+            {"Content-Type", "text/plain"},
+        };
+
+        UplinkStringResult result = edge_presign_url(&credentials, "PUT", "mybucket", "mykey", 60, headers, 1);
+        require_noerror(result.error);
+        require(strstr(result.string, "&X-Amz-SignedHeaders=content-type%3Bhost&") != NULL);
+
+        uplink_free_string_result(result);
+    }
+
+    {
+        // Invalid arguments
+        UplinkStringResult result = edge_presign_url(NULL, "GET", "mybucket", "mykey", 60, NULL, 0);
+        require_error(result.error, UPLINK_ERROR_INTERNAL);
+        require(result.string == NULL);
+        uplink_free_string_result(result);
+
+        result = edge_presign_url(&credentials, "POST", "mybucket", "mykey", 60, NULL, 0);
+        require_error(result.error, UPLINK_ERROR_INTERNAL);
+        uplink_free_string_result(result);
+
+        result = edge_presign_url(&credentials, "GET", "mybucket", "mykey", 604801, NULL, 0);
+        require_error(result.error, UPLINK_ERROR_INTERNAL);
+        uplink_free_string_result(result);
+
+        // would overflow when converted to nanoseconds
+        result = edge_presign_url(&credentials, "GET", "mybucket", "mykey", INT64_MAX, NULL, 0);
+        require_error(result.error, UPLINK_ERROR_INTERNAL);
+        uplink_free_string_result(result);
+
+        result = edge_presign_url(&credentials, "GET", "mybucket", "mykey", 0, NULL, 0);
+        require_error(result.error, UPLINK_ERROR_INTERNAL);
+        uplink_free_string_result(result);
+
+        result = edge_presign_url(&credentials, "GET", "", "mykey", 60, NULL, 0);
+        require_error(result.error, UPLINK_ERROR_INTERNAL);
+        uplink_free_string_result(result);
+    }
+
+    return 0;
+}
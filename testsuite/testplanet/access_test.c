@@ -4,6 +4,7 @@
 #include <stdio.h>
 #include <stdlib.h>
 #include <string.h>
+#include <time.h>
 
 #include "../require.h"
 #include "helpers.h"
@@ -145,6 +146,346 @@ void test_access_share(UplinkAccess *access)
     }
 }
 
+void require_share_error(UplinkAccess *access, UplinkPermission permission, const char *field)
+{
+    UplinkAccessResult shared_access_result = uplink_access_share(access, permission, NULL, 0);
+    require_error(shared_access_result.error, UPLINK_ERROR_INTERNAL);
+    requiref(strstr(shared_access_result.error->message, field) != NULL, "unexpected error: %s\n",
+             shared_access_result.error->message);
+    require(shared_access_result.access == NULL);
+    uplink_free_access_result(shared_access_result);
+}
+
+void test_access_share_extended(UplinkAccess *access)
+{
+    {
+        UplinkPermission permission = {
+            .allow_download = true,
+            .max_object_ttl = 3600,
+        };
+        require_share_error(access, permission, "max_object_ttl");
+
+        permission.allow_upload = true;
+        permission.max_object_ttl = -1;
+        require_share_error(access, permission, "max_object_ttl");
+    }
+
+    {
+        UplinkPermission permission = {
+            .allow_download = true,
+            .allow_bypass_governance_retention = true,
+        };
+        require_share_error(access, permission, "allow_bypass_governance_retention");
+    }
+
+    {
+        UplinkPermission permission = {
+            .allow_download = true,
+            .not_before = 2000000000,
+            .not_after = 1000000000,
+        };
+        require_share_error(access, permission, "not_after");
+    }
+
+    {
+        UplinkPermission permission = {
+            .allow_get_object_retention = true,
+            .allow_get_object_legal_hold = true,
+            .allow_put_object_retention = true,
+            .allow_bypass_governance_retention = true,
+        };
+        UplinkAccessResult shared_access_result = uplink_access_share(access, permission, NULL, 0);
+        require_noerror(shared_access_result.error);
+
+        UplinkStringResult inspect_result = uplink_access_inspect(shared_access_result.access);
+        require_noerror(inspect_result.error);
+        require(strstr(inspect_result.string, "\"allow_get_object_retention\":true") != NULL);
+        require(strstr(inspect_result.string, "\"allow_get_object_legal_hold\":true") != NULL);
+        require(strstr(inspect_result.string, "\"allow_put_object_legal_hold\":false") != NULL);
+        require(strstr(inspect_result.string, "\"allow_bypass_governance_retention\":true") != NULL);
+        uplink_free_string_result(inspect_result);
+
+        uplink_free_access_result(shared_access_result);
+    }
+
+    {
+        // objects uploaded with a max object ttl expire
+        UplinkPermission permission = {
+            .allow_upload = true,
+            .allow_list = true,
+            .max_object_ttl = 3600,
+        };
+        UplinkSharePrefix prefixes[] = {
+            {"alpha", "ingest/"},
+        };
+        UplinkAccessResult shared_access_result = uplink_access_share(access, permission, prefixes, 1);
+        require_noerror(shared_access_result.error);
+
+        UplinkProjectResult project_result = uplink_open_project(shared_access_result.access);
+        require_noerror(project_result.error);
+
+        UplinkUploadResult upload_result = uplink_upload_object(project_result.project, "alpha", "ingest/ttl.txt", NULL);
+        require_noerror(upload_result.error);
+        UplinkWriteResult write_result = uplink_upload_write(upload_result.upload, "ttl", 3);
+        require_noerror(write_result.error);
+        uplink_free_write_result(write_result);
+        UplinkError *commit_err = uplink_upload_commit(upload_result.upload);
+        require_noerror(commit_err);
+        uplink_free_upload_result(upload_result);
+
+        UplinkObjectResult object_result = uplink_stat_object(project_result.project, "alpha", "ingest/ttl.txt");
+        require_noerror(object_result.error);
+        int64_t now = (int64_t)time(NULL);
+        require(object_result.object->system.expires > now);
+        require(object_result.object->system.expires <= now + 3600 + 60);
+        uplink_free_object_result(object_result);
+
+        UplinkError *close_err = uplink_close_project(project_result.project);
+        require_noerror(close_err);
+        uplink_free_project_result(project_result);
+        uplink_free_access_result(shared_access_result);
+    }
+}
+
+void test_access_share_prefixes(UplinkAccess *access)
+{
+    UplinkPermission read_write = {
+        .allow_download = true,
+        .allow_upload = true,
+        .allow_list = true,
+        .allow_delete = true,
+    };
+    UplinkPermission read_only = {
+        .allow_download = true,
+        .allow_list = true,
+    };
+
+    {
+        UplinkSharePrefixesResult shared_result = uplink_access_share_prefixes(access, NULL, 0);
+        require_error(shared_result.error, UPLINK_ERROR_INTERNAL);
+        require(shared_result.accesses == NULL);
+        uplink_free_share_prefixes_result(shared_result);
+    }
+
+    {
+        // an access grant for each permission
+        UplinkSharePrefixPermission entries[] = {
+            {{"alpha", "tenant/uploads/"}, read_write},
+            {{"alpha", "tenant/shared/"}, read_only},
+            {{"alpha", "tenant/public/"}, read_only},
+        };
+        UplinkSharePrefixesResult shared_result = uplink_access_share_prefixes(access, entries, 3);
+        require_noerror(shared_result.error);
+        require(shared_result.accesses_count == 2);
+
+        UplinkStringResult inspect_result = uplink_access_inspect(shared_result.accesses[0]);
+        require_noerror(inspect_result.error);
+        require(strstr(inspect_result.string, "\"allow_upload\":true") != NULL);
+        require(strstr(inspect_result.string, "\"prefix\":\"tenant/uploads\"") != NULL);
+        require(strstr(inspect_result.string, "\"prefix\":\"tenant/shared\"") == NULL);
+        uplink_free_string_result(inspect_result);
+
+        inspect_result = uplink_access_inspect(shared_result.accesses[1]);
+        require_noerror(inspect_result.error);
+        require(strstr(inspect_result.string, "\"allow_upload\":false") != NULL);
+        require(strstr(inspect_result.string, "\"prefix\":\"tenant/uploads\"") == NULL);
+        require(strstr(inspect_result.string, "\"prefix\":\"tenant/shared\"") != NULL);
+        require(strstr(inspect_result.string, "\"prefix\":\"tenant/public\"") != NULL);
+        uplink_free_string_result(inspect_result);
+
+        uplink_free_share_prefixes_result(shared_result);
+    }
+
+    {
+        // an invalid permission fails all
+        UplinkPermission invalid = {
+            .allow_download = true,
+            .max_object_ttl = 60,
+        };
+        UplinkSharePrefixPermission entries[] = {
+            {{"alpha", "tenant/uploads/"}, read_write},
+            {{"alpha", "tenant/shared/"}, invalid},
+        };
+        UplinkSharePrefixesResult shared_result = uplink_access_share_prefixes(access, entries, 2);
+        require_error(shared_result.error, UPLINK_ERROR_INTERNAL);
+        require(shared_result.accesses == NULL);
+        uplink_free_share_prefixes_result(shared_result);
+    }
+}
+
+void test_access_inspect(UplinkAccess *access)
+{
+    {
+        UplinkStringResult inspect_result = uplink_access_inspect(NULL);
+        require_error(inspect_result.error, UPLINK_ERROR_INTERNAL);
+        require(inspect_result.string == NULL);
+        uplink_free_string_result(inspect_result);
+    }
+
+    {
+        UplinkStringResult inspect_result = uplink_access_inspect(access);
+        require_noerror(inspect_result.error);
+        require(strstr(inspect_result.string, "\"prefixes\":null") != NULL);
+        require(strstr(inspect_result.string, "\"has_encryption_key_overrides\":false") != NULL);
+        uplink_free_string_result(inspect_result);
+    }
+
+    {
+        UplinkPermission permission = {
+            .allow_download = true,
+            .not_after = 2000000000,
+        };
+        UplinkSharePrefix prefixes[] = {
+            {"alpha", "photos/"},
+        };
+        UplinkAccessResult shared_access_result = uplink_access_share(access, permission, prefixes, 1);
+        require_noerror(shared_access_result.error);
+
+        UplinkStringResult inspect_result = uplink_access_inspect(shared_access_result.access);
+        require_noerror(inspect_result.error);
+        require(strstr(inspect_result.string, "\"allow_download\":true") != NULL);
+        require(strstr(inspect_result.string, "\"allow_upload\":false") != NULL);
+        require(strstr(inspect_result.string, "\"not_after\":2000000000") != NULL);
+        require(strstr(inspect_result.string, "\"bucket\":\"alpha\",\"prefix\":\"photos\"") != NULL);
+        uplink_free_string_result(inspect_result);
+
+        uplink_free_access_result(shared_access_result);
+    }
+}
+
+void test_access_subset(UplinkAccess *access)
+{
+    {
+        UplinkBoolResult subset_result = uplink_access_is_subset(NULL, access);
+        require_error(subset_result.error, UPLINK_ERROR_INTERNAL);
+        uplink_free_bool_result(subset_result);
+
+        UplinkTimeResult expires_result = uplink_access_expires_at(NULL);
+        require_error(expires_result.error, UPLINK_ERROR_INTERNAL);
+        uplink_free_time_result(expires_result);
+    }
+
+    UplinkPermission permission = {
+        .allow_download = true,
+        .allow_list = true,
+        .not_after = 2000000000,
+    };
+    UplinkSharePrefix prefixes[] = {
+        {"alpha", "tenant/"},
+    };
+    UplinkAccessResult parent_result = uplink_access_share(access, permission, prefixes, 1);
+    require_noerror(parent_result.error);
+
+    permission.allow_list = false;
+    permission.not_after = 1900000000;
+    UplinkAccessResult child_result = uplink_access_share(parent_result.access, permission, prefixes, 1);
+    require_noerror(child_result.error);
+
+    {
+        UplinkBoolResult subset_result = uplink_access_is_subset(child_result.access, parent_result.access);
+        require_noerror(subset_result.error);
+        require(subset_result.value);
+        uplink_free_bool_result(subset_result);
+
+        subset_result = uplink_access_is_subset(parent_result.access, child_result.access);
+        require_noerror(subset_result.error);
+        require(!subset_result.value);
+        uplink_free_bool_result(subset_result);
+
+        subset_result = uplink_access_is_subset(access, parent_result.access);
+        require_noerror(subset_result.error);
+        require(!subset_result.value);
+        uplink_free_bool_result(subset_result);
+    }
+
+    {
+        UplinkTimeResult expires_result = uplink_access_expires_at(access);
+        require_noerror(expires_result.error);
+        require(expires_result.time == 0);
+        uplink_free_time_result(expires_result);
+
+        // the earliest not_after of all caveats is used
+        expires_result = uplink_access_expires_at(child_result.access);
+        require_noerror(expires_result.error);
+        require(expires_result.time == 1900000000);
+        uplink_free_time_result(expires_result);
+    }
+
+    uplink_free_access_result(child_result);
+    uplink_free_access_result(parent_result);
+}
+
+void require_inspect_contains(UplinkAccess *access, const char *expected)
+{
+    UplinkStringResult inspect_result = uplink_access_inspect(access);
+    require_noerror(inspect_result.error);
+    requiref(strstr(inspect_result.string, expected) != NULL, "%s not in %s\n", expected, inspect_result.string);
+    uplink_free_string_result(inspect_result);
+}
+
+void test_access_object_keys(const char *access_string)
+{
+    {
+        UplinkAccessResult disabled_result = uplink_access_disable_object_key_encryption(NULL);
+        require_error(disabled_result.error, UPLINK_ERROR_INTERNAL);
+        require(disabled_result.access == NULL);
+        uplink_free_access_result(disabled_result);
+
+        UplinkError *error = uplink_access_enable_path_encryption_bypass(NULL);
+        require_error(error, UPLINK_ERROR_INTERNAL);
+        uplink_free_error(error);
+    }
+
+    UplinkAccessResult parent_result = uplink_parse_access(access_string);
+    require_noerror(parent_result.error);
+
+    UplinkAccessResult access_result = uplink_access_disable_object_key_encryption(parent_result.access);
+    require_noerror(access_result.error);
+    require_inspect_contains(access_result.access, "\"unencrypted_object_keys\":true");
+    require_inspect_contains(access_result.access, "\"path_encryption_bypass\":false");
+
+    // the parent access still encrypts object keys
+    require_inspect_contains(parent_result.access, "\"unencrypted_object_keys\":false");
+    uplink_free_access_result(parent_result);
+
+    UplinkError *error = uplink_access_enable_path_encryption_bypass(access_result.access);
+    require_noerror(error);
+    require_inspect_contains(access_result.access, "\"unencrypted_object_keys\":true");
+    require_inspect_contains(access_result.access, "\"path_encryption_bypass\":true");
+
+    {
+        UplinkPermission permission = {
+            .allow_download = true,
+            .allow_list = true,
+        };
+        UplinkSharePrefix prefixes[] = {
+            {"public", "datasets/"},
+        };
+        UplinkAccessResult shared_access_result = uplink_access_share(access_result.access, permission, prefixes, 1);
+        require_noerror(shared_access_result.error);
+        require_inspect_contains(shared_access_result.access, "\"unencrypted_object_keys\":true");
+        require_inspect_contains(shared_access_result.access, "\"path_encryption_bypass\":true");
+        require_inspect_contains(shared_access_result.access, "\"bucket\":\"public\",\"prefix\":\"datasets\"");
+        uplink_free_access_result(shared_access_result);
+    }
+
+    {
+        // path encryption bypass is not part of the serialized access grant
+        UplinkStringResult serialized = uplink_access_serialize(access_result.access);
+        require_noerror(serialized.error);
+
+        UplinkAccessResult parsed_result = uplink_parse_access(serialized.string);
+        require_noerror(parsed_result.error);
+        require_inspect_contains(parsed_result.access, "\"unencrypted_object_keys\":true");
+        require_inspect_contains(parsed_result.access, "\"path_encryption_bypass\":false");
+
+        uplink_free_access_result(parsed_result);
+        uplink_free_string_result(serialized);
+    }
+
+    uplink_free_access_result(access_result);
+}
+
 int main(void)
 {
     const char *access_string = getenv("UPLINK_0_ACCESS");
@@ -166,6 +507,21 @@ int main(void)
     // test access share function
     test_access_share(access);
 
+    // test access share function with extended permissions
+    test_access_share_extended(access);
+
+    // test access share function with a permission per prefix
+    test_access_share_prefixes(access);
+
+    // test access subset and expiry functions
+    test_access_subset(access);
+
+    // test access inspect function
+    test_access_inspect(access);
+
+    // test object key encryption settings
+    test_access_object_keys(access_string);
+
     uplink_free_access_result(access_result);
 
     requiref(uplink_internal_UniverseIsEmpty(), "universe is not empty\n");
@@ -0,0 +1,110 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+#include <pthread.h>
+#include <stdlib.h>
+#include <string.h>
+
+#include "../require.h"
+#include "helpers.h"
+#include "uplink.h"
+
+static pthread_mutex_t counter_lock = PTHREAD_MUTEX_INITIALIZER;
+static long allocations = 0;
+static long frees = 0;
+
+static void *counting_malloc(size_t size)
+{
+    pthread_mutex_lock(&counter_lock);
+    allocations++;
+    pthread_mutex_unlock(&counter_lock);
+    return malloc(size);
+}
+
+static void counting_free(void *ptr)
+{
+    if (ptr == NULL) {
+        return;
+    }
+    pthread_mutex_lock(&counter_lock);
+    frees++;
+    pthread_mutex_unlock(&counter_lock);
+    free(ptr);
+}
+
+void handle_project(UplinkProject *project);
+
+int main(void)
+{
+    {
+        UplinkAllocator invalid = {.malloc_fn = counting_malloc};
+        UplinkError *err = uplink_set_allocator(&invalid);
+        require_error(err, UPLINK_ERROR_INTERNAL);
+        uplink_free_error(err);
+    }
+
+    UplinkAllocator allocator = {
+        .malloc_fn = counting_malloc,
+        .free_fn = counting_free,
+    };
+    UplinkError *err = uplink_set_allocator(&allocator);
+    require_noerror(err);
+
+    with_test_project(&handle_project);
+
+    requiref(allocations > 0, "custom allocator was not used\n");
+    requiref(allocations == frees, "allocations %ld != frees %ld\n", allocations, frees);
+
+    err = uplink_set_allocator(NULL);
+    require_noerror(err);
+
+    return 0;
+}
+
+void handle_project(UplinkProject *project)
+{
+    UplinkBucketResult bucket_result = uplink_ensure_bucket(project, "alpha");
+    require_noerror(bucket_result.error);
+    uplink_free_bucket_result(bucket_result);
+
+    UplinkUploadResult upload_result = uplink_upload_object(project, "alpha", "data.txt", NULL);
+    require_noerror(upload_result.error);
+
+    UplinkCustomMetadataEntry entries[] = {
+        {.key = "key", .key_length = 3, .value = "value", .value_length = 5},
+    };
+    UplinkCustomMetadata custom = {.entries = entries, .count = 1};
+    UplinkError *err = uplink_upload_set_custom_metadata(upload_result.upload, custom);
+    require_noerror(err);
+
+    UplinkWriteResult write_result = uplink_upload_write(upload_result.upload, "hello", 5);
+    require_noerror(write_result.error);
+    uplink_free_write_result(write_result);
+
+    err = uplink_upload_commit(upload_result.upload);
+    require_noerror(err);
+    uplink_free_upload_result(upload_result);
+
+    UplinkObjectResult object_result = uplink_stat_object(project, "alpha", "data.txt");
+    require_noerror(object_result.error);
+    require(strcmp(object_result.object->key, "data.txt") == 0);
+    require(object_result.object->custom.count == 1);
+    uplink_free_object_result(object_result);
+
+    object_result = uplink_stat_object(project, "alpha", "missing.txt");
+    require_error(object_result.error, UPLINK_ERROR_OBJECT_NOT_FOUND);
+    uplink_free_object_result(object_result);
+
+    UplinkObjectIterator *it = uplink_list_objects(project, "alpha", NULL);
+    while (uplink_object_iterator_next(it)) {
+        UplinkObject *object = uplink_object_iterator_item(it);
+        uplink_free_object(object);
+    }
+    err = uplink_object_iterator_err(it);
+    require_noerror(err);
+    uplink_free_object_iterator(it);
+
+    object_result = uplink_delete_object(project, "alpha", "data.txt");
+    require_noerror(object_result.error);
+    uplink_free_object_result(object_result);
+}
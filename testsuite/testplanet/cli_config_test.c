@@ -0,0 +1,78 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+#include <stdio.h>
+#include <stdlib.h>
+#include <string.h>
+
+#include "../require.h"
+#include "helpers.h"
+#include "uplink.h"
+
+void write_file(const char *dir, const char *name, const char *content)
+{
+    char path[4096];
+    snprintf(path, sizeof(path), "%s/%s", dir, name);
+
+    FILE *file = fopen(path, "w");
+    require(file != NULL);
+    require(fputs(content, file) >= 0);
+    require(fclose(file) == 0);
+}
+
+int main(void)
+{
+    const char *access_string = getenv("UPLINK_0_ACCESS");
+    const char *tmp_dir = getenv("TMP_DIR");
+
+    char access_json[8192];
+    snprintf(access_json, sizeof(access_json), "{\"default\": \"main\", \"accesses\": {\"main\": \"%s\"}}",
+             access_string);
+    write_file(tmp_dir, "access.json", access_json);
+    write_file(tmp_dir, "config.ini", "[client]\nuser-agent = cli-import\ndial-timeout = 12s\n");
+
+    UplinkConfig base = {
+        .user_agent = "base",
+        .dial_timeout_milliseconds = 1000,
+        .temp_directory = tmp_dir,
+    };
+
+    {
+        UplinkCLIConfigResult result = uplink_import_cli_config(base, tmp_dir, "missing");
+        require_error(result.error, UPLINK_ERROR_INTERNAL);
+        require(result.access == NULL);
+        uplink_free_cli_config_result(result);
+    }
+
+    UplinkCLIConfigResult result = uplink_import_cli_config(base, tmp_dir, NULL);
+    require_noerror(result.error);
+    require(strcmp(result.config.user_agent, "cli-import") == 0);
+    require(result.config.dial_timeout_milliseconds == 12000);
+    require(strcmp(result.config.temp_directory, tmp_dir) == 0);
+
+    {
+        UplinkStringResult serialized = uplink_access_serialize(result.access);
+        require_noerror(serialized.error);
+        require(strcmp(serialized.string, access_string) == 0);
+        uplink_free_string_result(serialized);
+    }
+
+    {
+        UplinkProjectResult project_result = uplink_config_open_project(result.config, result.access);
+        require_noerror(project_result.error);
+
+        UplinkBucketResult bucket_result = uplink_ensure_bucket(project_result.project, "cli");
+        require_noerror(bucket_result.error);
+        uplink_free_bucket_result(bucket_result);
+
+        UplinkError *error = uplink_close_project(project_result.project);
+        require_noerror(error);
+        uplink_free_project_result(project_result);
+    }
+
+    uplink_free_cli_config_result(result);
+
+    requiref(uplink_internal_UniverseIsEmpty(), "universe is not empty\n");
+
+    return 0;
+}
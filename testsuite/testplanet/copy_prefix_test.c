@@ -0,0 +1,142 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "../require.h"
+#include "helpers.h"
+#include "uplink.h"
+
+void handle_project(UplinkProject *project);
+void upload_hello(UplinkProject *project, const char *bucket_name, const char *object_key);
+void require_object_exists(UplinkProject *project, const char *bucket_name, const char *object_key, bool exists);
+
+int main(void)
+{
+    with_test_project(&handle_project);
+    return 0;
+}
+
+void handle_project(UplinkProject *project)
+{
+    const char *bucket_names[] = {"alpha", "beta"};
+    for (int i = 0; i < 2; i++) {
+        UplinkBucketResult bucket_result = uplink_ensure_bucket(project, bucket_names[i]);
+        require_noerror(bucket_result.error);
+        uplink_free_bucket_result(bucket_result);
+    }
+
+    upload_hello(project, "alpha", "photos/one");
+    upload_hello(project, "alpha", "photos/two");
+    upload_hello(project, "alpha", "photos/2020/three");
+    upload_hello(project, "alpha", "other");
+
+    { // invalid arguments
+        UplinkPrefixReportResult result = uplink_copy_prefix(NULL, "alpha", "photos/", "beta", "photos/", NULL);
+        require_error(result.error, UPLINK_ERROR_INTERNAL);
+        require(result.report == NULL);
+        uplink_free_prefix_report_result(result);
+
+        result = uplink_copy_prefix(project, "alpha", "photos", "beta", "photos/", NULL);
+        require_error(result.error, UPLINK_ERROR_INTERNAL);
+        require(result.report == NULL);
+        uplink_free_prefix_report_result(result);
+
+        result = uplink_move_prefix(project, "alpha", "photos/", "alpha", "photos/old/", NULL);
+        require_error(result.error, UPLINK_ERROR_INTERNAL);
+        require(result.report == NULL);
+        uplink_free_prefix_report_result(result);
+
+        result = uplink_move_prefix(project, "alpha", "photos/", "beta", NULL, NULL);
+        require_error(result.error, UPLINK_ERROR_INTERNAL);
+        uplink_free_prefix_report_result(result);
+    }
+
+    { // copy to another bucket and resume after the cursor
+        UplinkCopyPrefixOptions options = {.concurrency = 2};
+        UplinkPrefixReportResult result = uplink_copy_prefix(project, "alpha", "photos/", "beta", "backup/", &options);
+        require_noerror(result.error);
+        require(result.report != NULL);
+        require(result.report->objects_listed == 3);
+        require(result.report->objects_completed == 3);
+        require(result.report->bytes_completed == 15);
+        require(result.report->objects_failed == 0);
+        require(result.report->failures_count == 0);
+        require(strlen(result.report->cursor) > 0);
+
+        require_object_exists(project, "beta", "backup/one", true);
+        require_object_exists(project, "beta", "backup/two", true);
+        require_object_exists(project, "beta", "backup/2020/three", true);
+        require_object_exists(project, "beta", "backup/other", false);
+        require_object_exists(project, "alpha", "photos/one", true);
+
+        // everything up to the cursor was copied
+        UplinkCopyPrefixOptions resume_options = {.cursor = result.report->cursor};
+        UplinkPrefixReportResult resumed =
+            uplink_copy_prefix(project, "alpha", "photos/", "beta", "backup/", &resume_options);
+        require_noerror(resumed.error);
+        require(resumed.report->objects_listed == 0);
+        require(strcmp(resumed.report->cursor, result.report->cursor) == 0);
+        uplink_free_prefix_report_result(resumed);
+
+        uplink_free_prefix_report_result(result);
+    }
+
+    { // move within the bucket
+        UplinkPrefixReportResult result = uplink_move_prefix(project, "alpha", "photos/", "alpha", "pictures/", NULL);
+        require_noerror(result.error);
+        require(result.report->objects_listed == 3);
+        require(result.report->objects_completed == 3);
+        require(result.report->objects_failed == 0);
+        uplink_free_prefix_report_result(result);
+
+        require_object_exists(project, "alpha", "pictures/one", true);
+        require_object_exists(project, "alpha", "pictures/two", true);
+        require_object_exists(project, "alpha", "pictures/2020/three", true);
+        require_object_exists(project, "alpha", "photos/one", false);
+        require_object_exists(project, "alpha", "other", true);
+    }
+
+    { // failures are reported
+        UplinkPrefixReportResult result =
+            uplink_copy_prefix(project, "alpha", "pictures/", "missing-bucket", "pictures/", NULL);
+        require(result.error != NULL);
+        require(result.report != NULL);
+        require(result.report->objects_listed == 3);
+        require(result.report->objects_completed == 0);
+        require(result.report->objects_failed == 3);
+        require(result.report->failures_count == 3);
+        require(result.report->failures[0].key != NULL);
+        require(result.report->failures[0].error != NULL);
+        require(strcmp(result.report->cursor, "") == 0);
+        uplink_free_prefix_report_result(result);
+    }
+}
+
+void upload_hello(UplinkProject *project, const char *bucket_name, const char *object_key)
+{
+    UplinkUploadResult upload_result = uplink_upload_object(project, bucket_name, object_key, NULL);
+    require_noerror(upload_result.error);
+
+    uint8_t hello[] = "hello";
+    UplinkWriteResult write_result = uplink_upload_write(upload_result.upload, hello, 5);
+    require_noerror(write_result.error);
+    uplink_free_write_result(write_result);
+
+    UplinkError *commit_error = uplink_upload_commit(upload_result.upload);
+    require_noerror(commit_error);
+
+    uplink_free_upload_result(upload_result);
+}
+
+void require_object_exists(UplinkProject *project, const char *bucket_name, const char *object_key, bool exists)
+{
+    UplinkObjectResult object_result = uplink_stat_object(project, bucket_name, object_key);
+    if (exists) {
+        require_noerror(object_result.error);
+    } else {
+        require_error(object_result.error, UPLINK_ERROR_OBJECT_NOT_FOUND);
+    }
+    uplink_free_object_result(object_result);
+}
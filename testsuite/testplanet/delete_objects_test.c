@@ -0,0 +1,127 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "../require.h"
+#include "helpers.h"
+#include "uplink.h"
+
+void handle_project(UplinkProject *project);
+void upload_hello(UplinkProject *project, const char *bucket_name, const char *object_key);
+void require_object_missing(UplinkProject *project, const char *bucket_name, const char *object_key);
+
+int main(void)
+{
+    with_test_project(&handle_project);
+    return 0;
+}
+
+void handle_project(UplinkProject *project)
+{
+    UplinkBucketResult bucket_result = uplink_ensure_bucket(project, "alpha");
+    require_noerror(bucket_result.error);
+    uplink_free_bucket_result(bucket_result);
+
+    { // invalid arguments
+        UplinkDeleteObjectsResult result = uplink_delete_objects(NULL, "alpha", NULL, 0, NULL);
+        require_error(result.error, UPLINK_ERROR_INTERNAL);
+        require(result.items == NULL);
+        uplink_free_delete_objects_result(result);
+
+        result = uplink_delete_objects(project, NULL, NULL, 0, NULL);
+        require_error(result.error, UPLINK_ERROR_INTERNAL);
+        uplink_free_delete_objects_result(result);
+
+        result = uplink_delete_objects(project, "alpha", NULL, 1, NULL);
+        require_error(result.error, UPLINK_ERROR_INTERNAL);
+        uplink_free_delete_objects_result(result);
+
+        result = uplink_delete_objects(project, "alpha", NULL, 0, NULL);
+        require_noerror(result.error);
+        require(result.items_count == 0);
+        require(result.failed_count == 0);
+        uplink_free_delete_objects_result(result);
+    }
+
+    { // with metadata
+        const char *keys[] = {"one", "two", "missing", NULL};
+        upload_hello(project, "alpha", "one");
+        upload_hello(project, "alpha", "two");
+
+        UplinkDeleteObjectsOptions options = {.concurrency = 2};
+        UplinkDeleteObjectsResult result = uplink_delete_objects(project, "alpha", keys, 4, &options);
+        require_noerror(result.error);
+        require(result.items_count == 4);
+        require(result.failed_count == 1);
+
+        for (int i = 0; i < 2; i++) {
+            UplinkDeleteObjectsItem *item = &result.items[i];
+            require(item->code == 0);
+            require(item->error == NULL);
+            require(item->deleted);
+            require(item->object != NULL);
+            require(strcmp(item->object->key, keys[i]) == 0);
+            require(item->object->system.content_length == 5);
+        }
+
+        require(result.items[2].code == 0);
+        require(!result.items[2].deleted);
+        require(result.items[2].object == NULL);
+
+        require(result.items[3].code == UPLINK_ERROR_INTERNAL);
+        require(result.items[3].error != NULL);
+
+        uplink_free_delete_objects_result(result);
+
+        require_object_missing(project, "alpha", "one");
+        require_object_missing(project, "alpha", "two");
+    }
+
+    { // skip metadata
+        const char *keys[] = {"three", "four"};
+        upload_hello(project, "alpha", "three");
+        upload_hello(project, "alpha", "four");
+
+        UplinkDeleteObjectsOptions options = {.skip_metadata = true};
+        UplinkDeleteObjectsResult result = uplink_delete_objects(project, "alpha", keys, 2, &options);
+        require_noerror(result.error);
+        require(result.items_count == 2);
+        require(result.failed_count == 0);
+
+        for (int i = 0; i < 2; i++) {
+            require(result.items[i].code == 0);
+            require(result.items[i].deleted);
+            require(result.items[i].object == NULL);
+        }
+
+        uplink_free_delete_objects_result(result);
+
+        require_object_missing(project, "alpha", "three");
+        require_object_missing(project, "alpha", "four");
+    }
+}
+
+void upload_hello(UplinkProject *project, const char *bucket_name, const char *object_key)
+{
+    UplinkUploadResult upload_result = uplink_upload_object(project, bucket_name, object_key, NULL);
+    require_noerror(upload_result.error);
+
+    uint8_t hello[] = "hello";
+    UplinkWriteResult write_result = uplink_upload_write(upload_result.upload, hello, 5);
+    require_noerror(write_result.error);
+    uplink_free_write_result(write_result);
+
+    UplinkError *commit_error = uplink_upload_commit(upload_result.upload);
+    require_noerror(commit_error);
+
+    uplink_free_upload_result(upload_result);
+}
+
+void require_object_missing(UplinkProject *project, const char *bucket_name, const char *object_key)
+{
+    UplinkObjectResult object_result = uplink_stat_object(project, bucket_name, object_key);
+    require_error(object_result.error, UPLINK_ERROR_OBJECT_NOT_FOUND);
+    uplink_free_object_result(object_result);
+}
@@ -0,0 +1,153 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "../require.h"
+#include "helpers.h"
+#include "uplink.h"
+
+typedef struct progress_state {
+    int calls;
+    int failures;
+    UplinkDeletePrefixProgress last;
+} progress_state;
+
+void handle_project(UplinkProject *project);
+void upload_hello(UplinkProject *project, const char *bucket_name, const char *object_key);
+void require_object_exists(UplinkProject *project, const char *bucket_name, const char *object_key, bool exists);
+
+static void progress_callback(const UplinkDeletePrefixProgress *progress, const char *key, int32_t error_code,
+                              void *user_data)
+{
+    progress_state *state = (progress_state *)user_data;
+    require(key != NULL);
+    require(strncmp(key, "tenant/", 7) == 0);
+    if (error_code != 0) {
+        state->failures++;
+    }
+    state->calls++;
+    state->last = *progress;
+}
+
+int main(void)
+{
+    with_test_project(&handle_project);
+    return 0;
+}
+
+void handle_project(UplinkProject *project)
+{
+    UplinkBucketResult bucket_result = uplink_ensure_bucket(project, "alpha");
+    require_noerror(bucket_result.error);
+    uplink_free_bucket_result(bucket_result);
+
+    const char *tenant_keys[] = {"tenant/one", "tenant/two", "tenant/nested/three"};
+    for (int i = 0; i < 3; i++) {
+        upload_hello(project, "alpha", tenant_keys[i]);
+    }
+    upload_hello(project, "alpha", "other/one");
+
+    { // invalid arguments
+        UplinkDeletePrefixResult result = uplink_delete_prefix(NULL, "alpha", "tenant/", NULL);
+        require_error(result.error, UPLINK_ERROR_INTERNAL);
+        uplink_free_delete_prefix_result(result);
+
+        result = uplink_delete_prefix(project, NULL, "tenant/", NULL);
+        require_error(result.error, UPLINK_ERROR_INTERNAL);
+        uplink_free_delete_prefix_result(result);
+
+        result = uplink_delete_prefix(project, "alpha", NULL, NULL);
+        require_error(result.error, UPLINK_ERROR_INTERNAL);
+        uplink_free_delete_prefix_result(result);
+
+        result = uplink_delete_prefix(project, "alpha", "tenant", NULL);
+        require_error(result.error, UPLINK_ERROR_INTERNAL);
+        uplink_free_delete_prefix_result(result);
+    }
+
+    { // dry run
+        progress_state state = {0};
+        UplinkDeletePrefixOptions options = {
+            .dry_run = true,
+            .progress = progress_callback,
+            .user_data = &state,
+        };
+        UplinkDeletePrefixResult result = uplink_delete_prefix(project, "alpha", "tenant/", &options);
+        require_noerror(result.error);
+        require(result.progress.objects_listed == 3);
+        require(result.progress.bytes_listed == 15);
+        require(result.progress.objects_deleted == 0);
+        require(result.progress.objects_failed == 0);
+        uplink_free_delete_prefix_result(result);
+
+        require(state.calls == 3);
+        require(state.failures == 0);
+        require(state.last.objects_listed == 3);
+
+        for (int i = 0; i < 3; i++) {
+            require_object_exists(project, "alpha", tenant_keys[i], true);
+        }
+    }
+
+    { // delete
+        progress_state state = {0};
+        UplinkDeletePrefixOptions options = {
+            .concurrency = 2,
+            .progress = progress_callback,
+            .user_data = &state,
+        };
+        UplinkDeletePrefixResult result = uplink_delete_prefix(project, "alpha", "tenant/", &options);
+        require_noerror(result.error);
+        require(result.progress.objects_listed == 3);
+        require(result.progress.objects_deleted == 3);
+        require(result.progress.bytes_deleted == 15);
+        require(result.progress.objects_failed == 0);
+        uplink_free_delete_prefix_result(result);
+
+        require(state.calls == 3);
+        require(state.failures == 0);
+        require(state.last.objects_deleted == 3);
+
+        for (int i = 0; i < 3; i++) {
+            require_object_exists(project, "alpha", tenant_keys[i], false);
+        }
+        require_object_exists(project, "alpha", "other/one", true);
+    }
+
+    { // nothing left under the prefix
+        UplinkDeletePrefixResult result = uplink_delete_prefix(project, "alpha", "tenant/", NULL);
+        require_noerror(result.error);
+        require(result.progress.objects_listed == 0);
+        require(result.progress.objects_deleted == 0);
+        uplink_free_delete_prefix_result(result);
+    }
+}
+
+void upload_hello(UplinkProject *project, const char *bucket_name, const char *object_key)
+{
+    UplinkUploadResult upload_result = uplink_upload_object(project, bucket_name, object_key, NULL);
+    require_noerror(upload_result.error);
+
+    uint8_t hello[] = "hello";
+    UplinkWriteResult write_result = uplink_upload_write(upload_result.upload, hello, 5);
+    require_noerror(write_result.error);
+    uplink_free_write_result(write_result);
+
+    UplinkError *commit_error = uplink_upload_commit(upload_result.upload);
+    require_noerror(commit_error);
+
+    uplink_free_upload_result(upload_result);
+}
+
+void require_object_exists(UplinkProject *project, const char *bucket_name, const char *object_key, bool exists)
+{
+    UplinkObjectResult object_result = uplink_stat_object(project, bucket_name, object_key);
+    if (exists) {
+        require_noerror(object_result.error);
+    } else {
+        require_error(object_result.error, UPLINK_ERROR_OBJECT_NOT_FOUND);
+    }
+    uplink_free_object_result(object_result);
+}
@@ -9,8 +9,331 @@
 #include "helpers.h"
 #include "uplink.h"
 
+void test_new_access(void)
+{
+    const char *satellite_addr = getenv("SATELLITE_0_ADDR");
+    const char *api_key = getenv("UPLINK_0_APIKEY");
+
+    char salt[] = {1, 2, 3};
+    UplinkEncryptionKeyResult key_result = uplink_derive_encryption_key("tenant-password", salt, 3);
+    require_noerror(key_result.error);
+
+    {
+        UplinkAccessResult access_result = uplink_new_access(satellite_addr, "invalid", key_result.encryption_key);
+        require_error(access_result.error, UPLINK_ERROR_INTERNAL);
+        uplink_free_access_result(access_result);
+    }
+
+    {
+        UplinkAccessResult access_result = uplink_new_access(satellite_addr, api_key, NULL);
+        require_error(access_result.error, UPLINK_ERROR_INTERNAL);
+        uplink_free_access_result(access_result);
+    }
+
+    UplinkAccessResult access_result = uplink_new_access(satellite_addr, api_key, key_result.encryption_key);
+    require_noerror(access_result.error);
+
+    UplinkProjectResult project_result = uplink_open_project(access_result.access);
+    require_noerror(project_result.error);
+
+    UplinkBucketResult bucket_result = uplink_ensure_bucket(project_result.project, "offline");
+    require_noerror(bucket_result.error);
+    uplink_free_bucket_result(bucket_result);
+
+    UplinkUploadResult upload_result = uplink_upload_object(project_result.project, "offline", "tenant.txt", NULL);
+    require_noerror(upload_result.error);
+    UplinkWriteResult write_result = uplink_upload_write(upload_result.upload, "hello", 5);
+    require_noerror(write_result.error);
+    uplink_free_write_result(write_result);
+    UplinkError *error = uplink_upload_commit(upload_result.upload);
+    require_noerror(error);
+    uplink_free_upload_result(upload_result);
+
+    {
+        // an access with the same key can read the object
+        UplinkAccessResult other_result = uplink_new_access(satellite_addr, api_key, key_result.encryption_key);
+        require_noerror(other_result.error);
+
+        UplinkProjectResult other_project = uplink_open_project(other_result.access);
+        require_noerror(other_project.error);
+
+        UplinkDownloadResult download_result = uplink_download_object(other_project.project, "offline", "tenant.txt", NULL);
+        require_noerror(download_result.error);
+
+        char buffer[16];
+        UplinkReadResult read_result = uplink_download_read(download_result.download, buffer, sizeof(buffer));
+        require(read_result.bytes_read == 5);
+        require(memcmp(buffer, "hello", 5) == 0);
+        uplink_free_read_result(read_result);
+
+        error = uplink_close_download(download_result.download);
+        require_noerror(error);
+        uplink_free_download_result(download_result);
+
+        error = uplink_close_project(other_project.project);
+        require_noerror(error);
+        uplink_free_project_result(other_project);
+        uplink_free_access_result(other_result);
+    }
+
+    UplinkBucketResult delete_result = uplink_delete_bucket_with_objects(project_result.project, "offline");
+    require_noerror(delete_result.error);
+    uplink_free_bucket_result(delete_result);
+
+    error = uplink_close_project(project_result.project);
+    require_noerror(error);
+    uplink_free_project_result(project_result);
+
+    uplink_free_access_result(access_result);
+    uplink_free_encryption_key_result(key_result);
+}
+
+void test_passphrase_access(void)
+{
+    const char *satellite_addr = getenv("SATELLITE_0_ADDR");
+    const char *api_key = getenv("UPLINK_0_APIKEY");
+
+    UplinkAccessResult passphrase_result = uplink_request_access_with_passphrase(satellite_addr, api_key, "tenant-password");
+    require_noerror(passphrase_result.error);
+
+    {
+        UplinkSaltResult salt_result = uplink_request_project_salt(NULL);
+        require_error(salt_result.error, UPLINK_ERROR_INTERNAL);
+        require(salt_result.salt == NULL);
+        uplink_free_salt_result(salt_result);
+    }
+
+    {
+        UplinkConfig config = {
+            .user_agent = (const char *)"Test/1.0",
+            .dial_timeout_milliseconds = 10000,
+        };
+        UplinkSaltResult salt_result = uplink_config_request_project_salt(config, passphrase_result.access);
+        require_noerror(salt_result.error);
+        require(salt_result.salt_length > 0);
+        uplink_free_salt_result(salt_result);
+    }
+
+    UplinkProjectResult project_result = uplink_open_project(passphrase_result.access);
+    require_noerror(project_result.error);
+
+    UplinkBucketResult bucket_result = uplink_ensure_bucket(project_result.project, "passphrase");
+    require_noerror(bucket_result.error);
+    uplink_free_bucket_result(bucket_result);
+
+    UplinkUploadResult upload_result = uplink_upload_object(project_result.project, "passphrase", "tenant.txt", NULL);
+    require_noerror(upload_result.error);
+    UplinkWriteResult write_result = uplink_upload_write(upload_result.upload, "hello", 5);
+    require_noerror(write_result.error);
+    uplink_free_write_result(write_result);
+    UplinkError *error = uplink_upload_commit(upload_result.upload);
+    require_noerror(error);
+    uplink_free_upload_result(upload_result);
+
+    UplinkSaltResult salt_result = uplink_request_project_salt(passphrase_result.access);
+    require_noerror(salt_result.error);
+    require(salt_result.salt_length > 0);
+
+    UplinkEncryptionKeyResult key_result =
+        uplink_derive_passphrase_key("tenant-password", salt_result.salt, salt_result.salt_length);
+    require_noerror(key_result.error);
+
+    {
+        // an access created offline with the same passphrase can read the object
+        UplinkAccessResult offline_result = uplink_new_access(satellite_addr, api_key, key_result.encryption_key);
+        require_noerror(offline_result.error);
+
+        UplinkProjectResult offline_project = uplink_open_project(offline_result.access);
+        require_noerror(offline_project.error);
+
+        UplinkDownloadResult download_result =
+            uplink_download_object(offline_project.project, "passphrase", "tenant.txt", NULL);
+        require_noerror(download_result.error);
+
+        char buffer[16];
+        UplinkReadResult read_result = uplink_download_read(download_result.download, buffer, sizeof(buffer));
+        require(read_result.bytes_read == 5);
+        require(memcmp(buffer, "hello", 5) == 0);
+        uplink_free_read_result(read_result);
+
+        error = uplink_close_download(download_result.download);
+        require_noerror(error);
+        uplink_free_download_result(download_result);
+
+        error = uplink_close_project(offline_project.project);
+        require_noerror(error);
+        uplink_free_project_result(offline_project);
+        uplink_free_access_result(offline_result);
+    }
+
+    {
+        // a key derived with uplink_derive_encryption_key doesn't match
+        UplinkEncryptionKeyResult other_key =
+            uplink_derive_encryption_key("tenant-password", salt_result.salt, salt_result.salt_length);
+        require_noerror(other_key.error);
+
+        UplinkAccessResult other_result = uplink_new_access(satellite_addr, api_key, other_key.encryption_key);
+        require_noerror(other_result.error);
+
+        UplinkProjectResult other_project = uplink_open_project(other_result.access);
+        require_noerror(other_project.error);
+
+        UplinkObjectResult object_result = uplink_stat_object(other_project.project, "passphrase", "tenant.txt");
+        require_error(object_result.error, UPLINK_ERROR_OBJECT_NOT_FOUND);
+        uplink_free_object_result(object_result);
+
+        error = uplink_close_project(other_project.project);
+        require_noerror(error);
+        uplink_free_project_result(other_project);
+        uplink_free_access_result(other_result);
+        uplink_free_encryption_key_result(other_key);
+    }
+
+    UplinkBucketResult delete_result = uplink_delete_bucket_with_objects(project_result.project, "passphrase");
+    require_noerror(delete_result.error);
+    uplink_free_bucket_result(delete_result);
+
+    error = uplink_close_project(project_result.project);
+    require_noerror(error);
+    uplink_free_project_result(project_result);
+
+    uplink_free_encryption_key_result(key_result);
+    uplink_free_salt_result(salt_result);
+    uplink_free_access_result(passphrase_result);
+}
+
+void test_encryption_key_bytes(UplinkAccess *access)
+{
+    uint8_t raw[32];
+    fill_random_data(raw, sizeof(raw));
+
+    {
+        UplinkEncryptionKeyResult key_result = uplink_encryption_key_from_bytes(raw, 16);
+        require_error(key_result.error, UPLINK_ERROR_INTERNAL);
+        require(key_result.encryption_key == NULL);
+        uplink_free_encryption_key_result(key_result);
+    }
+
+    UplinkEncryptionKeyResult key_result = uplink_encryption_key_from_bytes(raw, sizeof(raw));
+    require_noerror(key_result.error);
+
+    {
+        uint8_t exported[32];
+        UplinkError *error = uplink_encryption_key_export(key_result.encryption_key, exported, 16);
+        require_error(error, UPLINK_ERROR_INTERNAL);
+        uplink_free_error(error);
+
+        error = uplink_encryption_key_export(key_result.encryption_key, exported, sizeof(exported));
+        require_noerror(error);
+        require(memcmp(raw, exported, sizeof(raw)) == 0);
+    }
+
+    UplinkStringResult serialized = uplink_encryption_key_serialize(key_result.encryption_key);
+    require_noerror(serialized.error);
+
+    UplinkEncryptionKeyResult parsed_result = uplink_parse_encryption_key(serialized.string);
+    require_noerror(parsed_result.error);
+
+    char salt[] = {4, 5, 6};
+    UplinkEncryptionKeyResult derived_result = uplink_derive_encryption_key("my-password", salt, 3);
+    require_noerror(derived_result.error);
+
+    {
+        UplinkBoolResult equal_result = uplink_encryption_key_equal(key_result.encryption_key, parsed_result.encryption_key);
+        require_noerror(equal_result.error);
+        require(equal_result.value);
+        uplink_free_bool_result(equal_result);
+
+        equal_result = uplink_encryption_key_equal(key_result.encryption_key, derived_result.encryption_key);
+        require_noerror(equal_result.error);
+        require(!equal_result.value);
+        uplink_free_bool_result(equal_result);
+    }
+
+    {
+        UplinkEncryptionKeyResult invalid_result = uplink_parse_encryption_key("invalid");
+        require_error(invalid_result.error, UPLINK_ERROR_INTERNAL);
+        uplink_free_encryption_key_result(invalid_result);
+    }
+
+    UplinkError *error = uplink_access_override_encryption_key(access, "bucket", "tenant/", parsed_result.encryption_key);
+    require_noerror(error);
+
+    uplink_free_encryption_key_result(derived_result);
+    uplink_free_encryption_key_result(parsed_result);
+    uplink_free_string_result(serialized);
+    uplink_free_encryption_key_result(key_result);
+}
+
+void test_encryption_key_overrides(const char *access_string)
+{
+    UplinkAccessResult access_result = uplink_parse_access(access_string);
+    require_noerror(access_result.error);
+    UplinkAccess *access = access_result.access;
+
+    char salt[] = {7, 8, 9};
+    UplinkEncryptionKeyResult key_result = uplink_derive_encryption_key("tenant", salt, 3);
+    require_noerror(key_result.error);
+
+    UplinkError *error = uplink_access_override_encryption_key(access, "bucket", "user-b/", key_result.encryption_key);
+    require_noerror(error);
+    error = uplink_access_override_encryption_key(access, "bucket", "user-a/", key_result.encryption_key);
+    require_noerror(error);
+
+    {
+        UplinkEncryptionKeyOverridesResult overrides_result = uplink_access_encryption_key_overrides(access);
+        require_noerror(overrides_result.error);
+        require(overrides_result.overrides_count == 2);
+        require(strcmp(overrides_result.overrides[0].bucket, "bucket") == 0);
+        require(strcmp(overrides_result.overrides[0].prefix, "user-a/") == 0);
+        require(strcmp(overrides_result.overrides[1].prefix, "user-b/") == 0);
+        uplink_free_encryption_key_overrides_result(overrides_result);
+    }
+
+    {
+        UplinkPermission permission = {.allow_download = true};
+        UplinkSharePrefix keep[] = {{"bucket", "user-b/"}};
+        UplinkAccessResult shared_result = uplink_access_share_with_overrides(access, permission, NULL, 0, keep, 1);
+        require_noerror(shared_result.error);
+
+        UplinkEncryptionKeyOverridesResult overrides_result = uplink_access_encryption_key_overrides(shared_result.access);
+        require_noerror(overrides_result.error);
+        require(overrides_result.overrides_count == 1);
+        require(strcmp(overrides_result.overrides[0].prefix, "user-b/") == 0);
+        uplink_free_encryption_key_overrides_result(overrides_result);
+
+        uplink_free_access_result(shared_result);
+
+        UplinkSharePrefix missing[] = {{"bucket", "user-c/"}};
+        shared_result = uplink_access_share_with_overrides(access, permission, NULL, 0, missing, 1);
+        require_error(shared_result.error, UPLINK_ERROR_INTERNAL);
+        uplink_free_access_result(shared_result);
+    }
+
+    {
+        error = uplink_access_remove_encryption_key_override(access, "bucket", "user-a/");
+        require_noerror(error);
+
+        error = uplink_access_remove_encryption_key_override(access, "bucket", "user-a/");
+        require_error(error, UPLINK_ERROR_INTERNAL);
+        uplink_free_error(error);
+
+        UplinkEncryptionKeyOverridesResult overrides_result = uplink_access_encryption_key_overrides(access);
+        require_noerror(overrides_result.error);
+        require(overrides_result.overrides_count == 1);
+        require(strcmp(overrides_result.overrides[0].prefix, "user-b/") == 0);
+        uplink_free_encryption_key_overrides_result(overrides_result);
+    }
+
+    uplink_free_encryption_key_result(key_result);
+    uplink_free_access_result(access_result);
+}
+
 int main(void)
 {
+    test_new_access();
+    test_passphrase_access();
+
     const char *access_string = getenv("UPLINK_0_ACCESS");
 
     UplinkAccessResult access_result = uplink_parse_access(access_string);
@@ -24,6 +347,9 @@ int main(void)
     UplinkError *error = uplink_access_override_encryption_key(access, "bucket", "prefix/", key_result.encryption_key);
     require_noerror(error);
 
+    test_encryption_key_bytes(access);
+    test_encryption_key_overrides(access_string);
+
     uplink_free_access_result(access_result);
     uplink_free_encryption_key_result(key_result);
 
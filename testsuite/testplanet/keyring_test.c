@@ -0,0 +1,98 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+#include <stdio.h>
+#include <stdlib.h>
+#include <string.h>
+
+#include "../require.h"
+#include "helpers.h"
+#include "uplink.h"
+
+int main(void)
+{
+    const char *access_string = getenv("UPLINK_0_ACCESS");
+    const char *tmp_dir = getenv("TMP_DIR");
+
+    char path[4096];
+    snprintf(path, sizeof(path), "%s/keyring.json", tmp_dir);
+
+    UplinkAccessResult access_result = uplink_parse_access(access_string);
+    require_noerror(access_result.error);
+
+    {
+        UplinkKeyringListResult list_result = uplink_keyring_list(path, "passphrase");
+        require_noerror(list_result.error);
+        require(list_result.entries_count == 0);
+        uplink_free_keyring_list_result(list_result);
+    }
+
+    {
+        UplinkError *error = uplink_keyring_save(path, "passphrase", "work", "work project", access_result.access);
+        require_noerror(error);
+
+        error = uplink_keyring_save(path, "passphrase", "home", NULL, access_result.access);
+        require_noerror(error);
+
+        error = uplink_keyring_save(path, "passphrase", "", NULL, access_result.access);
+        require_error(error, UPLINK_ERROR_INTERNAL);
+        uplink_free_error(error);
+    }
+
+    {
+        UplinkKeyringListResult list_result = uplink_keyring_list(path, "passphrase");
+        require_noerror(list_result.error);
+        require(list_result.entries_count == 2);
+        require(strcmp(list_result.entries[0].name, "home") == 0);
+        require(strcmp(list_result.entries[0].description, "") == 0);
+        require(strcmp(list_result.entries[1].name, "work") == 0);
+        require(strcmp(list_result.entries[1].description, "work project") == 0);
+        require(list_result.entries[1].created > 0);
+
+        UplinkStringResult address_result = uplink_access_satellite_address(access_result.access);
+        require_noerror(address_result.error);
+        require(strcmp(list_result.entries[1].satellite_address, address_result.string) == 0);
+        uplink_free_string_result(address_result);
+
+        uplink_free_keyring_list_result(list_result);
+    }
+
+    {
+        UplinkAccessResult loaded_result = uplink_keyring_load(path, "passphrase", "work");
+        require_noerror(loaded_result.error);
+
+        UplinkStringResult serialized = uplink_access_serialize(loaded_result.access);
+        require_noerror(serialized.error);
+        require(strcmp(serialized.string, access_string) == 0);
+        uplink_free_string_result(serialized);
+
+        uplink_free_access_result(loaded_result);
+    }
+
+    {
+        UplinkAccessResult loaded_result = uplink_keyring_load(path, "wrong", "work");
+        require_error(loaded_result.error, UPLINK_ERROR_INTERNAL);
+        require(loaded_result.access == NULL);
+        uplink_free_access_result(loaded_result);
+    }
+
+    {
+        UplinkError *error = uplink_keyring_delete(path, "passphrase", "work");
+        require_noerror(error);
+
+        UplinkAccessResult loaded_result = uplink_keyring_load(path, "passphrase", "work");
+        require_error(loaded_result.error, UPLINK_ERROR_INTERNAL);
+        uplink_free_access_result(loaded_result);
+
+        UplinkKeyringListResult list_result = uplink_keyring_list(path, "passphrase");
+        require_noerror(list_result.error);
+        require(list_result.entries_count == 1);
+        uplink_free_keyring_list_result(list_result);
+    }
+
+    uplink_free_access_result(access_result);
+
+    requiref(uplink_internal_UniverseIsEmpty(), "universe is not empty\n");
+
+    return 0;
+}
@@ -0,0 +1,132 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+#include <pthread.h>
+#include <stdlib.h>
+#include <string.h>
+
+#include "../require.h"
+#include "helpers.h"
+#include "uplink.h"
+
+typedef struct {
+    pthread_mutex_t lock;
+    int events;
+    int opening_project;
+    int dialed;
+    int segments;
+    int upload_committed;
+    int error_codes[256];
+    int levels[UPLINK_LOG_LEVEL_ERROR + 1];
+} log_state;
+
+static void log_callback(int32_t level, const char *message, const UplinkLogField *fields, size_t fields_count,
+                         void *user_data)
+{
+    log_state *state = (log_state *)user_data;
+
+    pthread_mutex_lock(&state->lock);
+    state->events++;
+    if (level >= UPLINK_LOG_LEVEL_DEBUG && level <= UPLINK_LOG_LEVEL_ERROR) {
+        state->levels[level]++;
+    }
+    if (strcmp(message, "opening project") == 0) {
+        state->opening_project++;
+    }
+    if (strcmp(message, "dialed") == 0) {
+        state->dialed++;
+    }
+    if (strcmp(message, "finished segment operation") == 0) {
+        state->segments++;
+    }
+    if (strcmp(message, "upload committed") == 0) {
+        state->upload_committed++;
+    }
+    if (strcmp(message, "returning error") == 0) {
+        for (size_t i = 0; i < fields_count; i++) {
+            if (strcmp(fields[i].key, "code") == 0) {
+                int code = atoi(fields[i].value);
+                if (code >= 0 && code < 256) {
+                    state->error_codes[code]++;
+                }
+            }
+        }
+    }
+    pthread_mutex_unlock(&state->lock);
+}
+
+log_state state = {.lock = PTHREAD_MUTEX_INITIALIZER};
+
+void handle_project(UplinkProject *project);
+
+int main(void)
+{
+    {
+        UplinkError *err = uplink_set_log_callback(42, log_callback, &state);
+        require_error(err, UPLINK_ERROR_INTERNAL);
+        uplink_free_error(err);
+    }
+
+    UplinkError *err = uplink_set_log_callback(UPLINK_LOG_LEVEL_DEBUG, log_callback, &state);
+    require_noerror(err);
+
+    with_test_project(&handle_project);
+
+    require(state.opening_project == 1);
+    require(state.dialed > 0);
+    require(state.segments > 0);
+    require(state.upload_committed == 1);
+    require(state.error_codes[UPLINK_ERROR_OBJECT_NOT_FOUND] == 1);
+    require(state.levels[UPLINK_LOG_LEVEL_ERROR] == 0);
+
+    {
+        // events below the level are not reported
+        err = uplink_set_log_callback(UPLINK_LOG_LEVEL_WARN, log_callback, &state);
+        require_noerror(err);
+
+        int events = state.events;
+        UplinkAccessResult access_result = uplink_parse_access("invalid");
+        require(access_result.error != NULL);
+        uplink_free_access_result(access_result);
+        require(state.events == events);
+    }
+
+    {
+        // disabling the callback stops all events
+        err = uplink_set_log_callback(UPLINK_LOG_LEVEL_DEBUG, NULL, NULL);
+        require_noerror(err);
+
+        int events = state.events;
+        UplinkAccessResult access_result = uplink_parse_access("invalid");
+        require(access_result.error != NULL);
+        uplink_free_access_result(access_result);
+        require(state.events == events);
+    }
+
+    return 0;
+}
+
+void handle_project(UplinkProject *project)
+{
+    UplinkBucketResult bucket_result = uplink_ensure_bucket(project, "alpha");
+    require_noerror(bucket_result.error);
+    uplink_free_bucket_result(bucket_result);
+
+    UplinkUploadResult upload_result = uplink_upload_object(project, "alpha", "logged.txt", NULL);
+    require_noerror(upload_result.error);
+
+    // large enough to be uploaded as a remote segment
+    static uint8_t data[64 * 1024];
+    fill_random_data(data, sizeof(data));
+    UplinkWriteResult write_result = uplink_upload_write(upload_result.upload, data, sizeof(data));
+    require_noerror(write_result.error);
+    uplink_free_write_result(write_result);
+
+    UplinkError *commit_error = uplink_upload_commit(upload_result.upload);
+    require_noerror(commit_error);
+    uplink_free_upload_result(upload_result);
+
+    UplinkObjectResult object_result = uplink_stat_object(project, "alpha", "missing.txt");
+    require_error(object_result.error, UPLINK_ERROR_OBJECT_NOT_FOUND);
+    uplink_free_object_result(object_result);
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+#include <string.h>
+
+#include "../require.h"
+#include "helpers.h"
+#include "uplink.h"
+
+typedef struct {
+    int metrics;
+    int empty;
+} metric_state;
+
+static void metric_callback(const char *series, const char *field, double value, void *user_data)
+{
+    metric_state *state = (metric_state *)user_data;
+    (void)value;
+
+    state->metrics++;
+    if (strlen(series) == 0 || strlen(field) == 0) {
+        state->empty++;
+    }
+}
+
+void handle_project(UplinkProject *project);
+
+int main(void)
+{
+    with_test_project(&handle_project);
+
+    {
+        UplinkStringResult result = uplink_metrics_json();
+        require_noerror(result.error);
+        require(result.string != NULL);
+        require(result.string[0] == '[');
+        require(strstr(result.string, "\"series\"") != NULL);
+        uplink_free_string_result(result);
+    }
+
+    {
+        metric_state state = {0};
+        UplinkError *err = uplink_metrics_iterate(metric_callback, &state);
+        require_noerror(err);
+        require(state.metrics > 0);
+        require(state.empty == 0);
+    }
+
+    {
+        UplinkError *err = uplink_metrics_iterate(NULL, NULL);
+        require_error(err, UPLINK_ERROR_INTERNAL);
+        uplink_free_error(err);
+    }
+
+    return 0;
+}
+
+void handle_project(UplinkProject *project)
+{
+    UplinkBucketResult bucket_result = uplink_ensure_bucket(project, "alpha");
+    require_noerror(bucket_result.error);
+    uplink_free_bucket_result(bucket_result);
+
+    UplinkUploadResult upload_result = uplink_upload_object(project, "alpha", "metrics.txt", NULL);
+    require_noerror(upload_result.error);
+
+    const char *data = "metrics";
+    UplinkWriteResult write_result = uplink_upload_write(upload_result.upload, (void *)data, strlen(data));
+    require_noerror(write_result.error);
+    uplink_free_write_result(write_result);
+
+    UplinkError *err = uplink_upload_commit(upload_result.upload);
+    require_noerror(err);
+    uplink_free_upload_result(upload_result);
+}
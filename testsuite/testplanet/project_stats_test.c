@@ -0,0 +1,111 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+#include <string.h>
+
+#include "../require.h"
+#include "helpers.h"
+#include "uplink.h"
+
+void handle_project(UplinkProject *project);
+UplinkProjectStatsResult get_stats(UplinkProject *project);
+
+int main(void)
+{
+    with_test_project(&handle_project);
+
+    {
+        UplinkProjectStatsResult result = uplink_project_stats(NULL);
+        require_error(result.error, UPLINK_ERROR_INTERNAL);
+        require(result.stats == NULL);
+        uplink_free_project_stats_result(result);
+    }
+
+    return 0;
+}
+
+UplinkProjectStatsResult get_stats(UplinkProject *project)
+{
+    UplinkProjectStatsResult result = uplink_project_stats(project);
+    require_noerror(result.error);
+    require(result.stats != NULL);
+    require(result.stats->operations_count == UPLINK_OPERATION_COUNT);
+    require(result.stats->errors_count == UPLINK_ERROR_CODE_LIMIT);
+    return result;
+}
+
+void handle_project(UplinkProject *project)
+{
+    const char *data = "hello world";
+    size_t data_len = strlen(data);
+
+    UplinkProjectStatsResult stats_result = get_stats(project);
+    require(stats_result.stats->bytes_uploaded == 0);
+    require(stats_result.stats->bytes_downloaded == 0);
+    uplink_free_project_stats_result(stats_result);
+
+    UplinkBucketResult bucket_result = uplink_ensure_bucket(project, "alpha");
+    require_noerror(bucket_result.error);
+    uplink_free_bucket_result(bucket_result);
+
+    {
+        UplinkUploadResult upload_result = uplink_upload_object(project, "alpha", "data.txt", NULL);
+        require_noerror(upload_result.error);
+
+        stats_result = get_stats(project);
+        require(stats_result.stats->uploads_in_progress == 1);
+        uplink_free_project_stats_result(stats_result);
+
+        UplinkWriteResult write_result = uplink_upload_write(upload_result.upload, (void *)data, data_len);
+        require_noerror(write_result.error);
+        uplink_free_write_result(write_result);
+
+        UplinkError *err = uplink_upload_commit(upload_result.upload);
+        require_noerror(err);
+        uplink_free_upload_result(upload_result);
+    }
+
+    {
+        UplinkDownloadResult download_result = uplink_download_object(project, "alpha", "data.txt", NULL);
+        require_noerror(download_result.error);
+
+        stats_result = get_stats(project);
+        require(stats_result.stats->downloads_in_progress == 1);
+        uplink_free_project_stats_result(stats_result);
+
+        char buffer[64];
+        while (true) {
+            UplinkReadResult read_result = uplink_download_read(download_result.download, buffer, sizeof(buffer));
+            if (read_result.error != NULL) {
+                require(read_result.error->code == EOF);
+                uplink_free_read_result(read_result);
+                break;
+            }
+            uplink_free_read_result(read_result);
+        }
+
+        UplinkError *err = uplink_close_download(download_result.download);
+        require_noerror(err);
+        uplink_free_download_result(download_result);
+    }
+
+    UplinkObjectResult object_result = uplink_stat_object(project, "alpha", "missing.txt");
+    require_error(object_result.error, UPLINK_ERROR_OBJECT_NOT_FOUND);
+    uplink_free_object_result(object_result);
+
+    stats_result = get_stats(project);
+    require(stats_result.stats->bytes_uploaded == (int64_t)data_len);
+    require(stats_result.stats->bytes_downloaded == (int64_t)data_len);
+    require(stats_result.stats->uploads_in_progress == 0);
+    require(stats_result.stats->downloads_in_progress == 0);
+
+    require(stats_result.stats->operations[UPLINK_OPERATION_ENSURE_BUCKET] == 1);
+    require(stats_result.stats->operations[UPLINK_OPERATION_UPLOAD_OBJECT] == 1);
+    require(stats_result.stats->operations[UPLINK_OPERATION_DOWNLOAD_OBJECT] == 1);
+    require(stats_result.stats->operations[UPLINK_OPERATION_STAT_OBJECT] == 1);
+    require(stats_result.stats->operations[UPLINK_OPERATION_DELETE_OBJECT] == 0);
+
+    require(stats_result.stats->errors[UPLINK_ERROR_OBJECT_NOT_FOUND] == 1);
+    require(stats_result.stats->errors[UPLINK_ERROR_INTERNAL] == 0);
+    uplink_free_project_stats_result(stats_result);
+}
@@ -10,6 +10,7 @@
 
 void handle_project(UplinkProject *project);
 void test_revoke_access(UplinkProject *project);
+void test_revoke_accesses(UplinkProject *project);
 UplinkAccessResult derive_access(UplinkAccess *access);
 void test_access_availability(UplinkAccess *access, bool expect_available);
 
@@ -23,6 +24,7 @@ void handle_project(UplinkProject *project)
 {
     require(project->_handle != 0);
     test_revoke_access(project);
+    test_revoke_accesses(project);
 }
 
 void test_revoke_access(UplinkProject *project)
@@ -79,6 +81,67 @@ void test_revoke_access(UplinkProject *project)
     uplink_free_access_result(access_result);
 }
 
+void test_revoke_accesses(UplinkProject *project)
+{
+    const char *access_string = getenv("UPLINK_0_ACCESS");
+
+    UplinkAccessResult access_result = uplink_parse_access(access_string);
+    require_noerror(access_result.error);
+
+    {
+        UplinkError *revoke_error = uplink_revoke_serialized_access(project, "invalid");
+        require_error(revoke_error, UPLINK_ERROR_INTERNAL);
+        uplink_free_error(revoke_error);
+
+        UplinkAccessResult derived_access_result = derive_access(access_result.access);
+        UplinkStringResult serialized = uplink_access_serialize(derived_access_result.access);
+        require_noerror(serialized.error);
+
+        revoke_error = uplink_revoke_serialized_access(project, serialized.string);
+        require_noerror(revoke_error);
+
+        test_access_availability(derived_access_result.access, false);
+        uplink_free_string_result(serialized);
+        uplink_free_access_result(derived_access_result);
+    }
+
+    {
+        UplinkAccessResult first_result = derive_access(access_result.access);
+        UplinkAccessResult second_result = derive_access(access_result.access);
+        UplinkStringResult first = uplink_access_serialize(first_result.access);
+        require_noerror(first.error);
+        UplinkStringResult second = uplink_access_serialize(second_result.access);
+        require_noerror(second.error);
+
+        const char *accesses[] = {first.string, "invalid", NULL, second.string};
+        UplinkRevokeAccessesResult revoke_result = uplink_revoke_accesses(project, accesses, 4);
+        require_noerror(revoke_result.error);
+        require(revoke_result.errors_count == 4);
+        require(revoke_result.failed_count == 2);
+        require_noerror(revoke_result.errors[0]);
+        require_error(revoke_result.errors[1], UPLINK_ERROR_INTERNAL);
+        require_error(revoke_result.errors[2], UPLINK_ERROR_INTERNAL);
+        require_noerror(revoke_result.errors[3]);
+        uplink_free_revoke_accesses_result(revoke_result);
+
+        test_access_availability(first_result.access, false);
+        test_access_availability(second_result.access, false);
+
+        uplink_free_string_result(second);
+        uplink_free_string_result(first);
+        uplink_free_access_result(second_result);
+        uplink_free_access_result(first_result);
+    }
+
+    {
+        UplinkRevokeAccessesResult revoke_result = uplink_revoke_accesses(NULL, NULL, 0);
+        require_error(revoke_result.error, UPLINK_ERROR_INTERNAL);
+        uplink_free_revoke_accesses_result(revoke_result);
+    }
+
+    uplink_free_access_result(access_result);
+}
+
 UplinkAccessResult derive_access(UplinkAccess *access)
 {
     UplinkPermission full_permissions = {
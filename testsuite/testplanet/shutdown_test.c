@@ -0,0 +1,121 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+#include <stdlib.h>
+#include <string.h>
+
+#include "../require.h"
+#include "helpers.h"
+#include "uplink.h"
+
+void upload_object(UplinkProject *project, const char *bucket, const char *key, size_t data_len);
+
+int main(void)
+{
+    // disable buffering
+    setvbuf(stdout, NULL, _IONBF, 0);
+
+    const char *access_string = getenv("UPLINK_0_ACCESS");
+
+    UplinkAccessResult access_result = uplink_parse_access(access_string);
+    require_noerror(access_result.error);
+
+    UplinkProjectResult project_result = uplink_open_project(access_result.access);
+    require_noerror(project_result.error);
+
+    UplinkProject *project = project_result.project;
+
+    UplinkBucketResult bucket_result = uplink_ensure_bucket(project, "alpha");
+    require_noerror(bucket_result.error);
+    uplink_free_bucket_result(bucket_result);
+
+    upload_object(project, "alpha", "existing.txt", 1024);
+
+    // leave an upload, a download and an iterator open
+    UplinkUploadResult upload_result = uplink_upload_object(project, "alpha", "pending.txt", NULL);
+    require_noerror(upload_result.error);
+
+    UplinkDownloadResult download_result = uplink_download_object(project, "alpha", "existing.txt", NULL);
+    require_noerror(download_result.error);
+
+    UplinkObjectIterator *iterator = uplink_list_objects(project, "alpha", NULL);
+    require(iterator != NULL);
+
+    {
+        UplinkError *err = uplink_shutdown();
+        require_noerror(err);
+    }
+
+    requiref(uplink_internal_UniverseIsEmpty(), "universe is not empty\n");
+
+    {
+        // handles that were live before shutdown are invalid
+        UplinkWriteResult write_result = uplink_upload_write(upload_result.upload, "x", 1);
+        require_error(write_result.error, UPLINK_ERROR_INVALID_HANDLE);
+        uplink_free_write_result(write_result);
+
+        UplinkBucketResult stat_result = uplink_stat_bucket(project, "alpha");
+        require_error(stat_result.error, UPLINK_ERROR_INVALID_HANDLE);
+        uplink_free_bucket_result(stat_result);
+    }
+
+    {
+        // shutting down an empty library is a no-op
+        UplinkError *err = uplink_shutdown();
+        require_noerror(err);
+    }
+
+    uplink_free_object_iterator(iterator);
+    uplink_free_download_result(download_result);
+    uplink_free_upload_result(upload_result);
+    uplink_free_project_result(project_result);
+    uplink_free_access_result(access_result);
+
+    {
+        // the library is usable after shutdown
+        UplinkAccessResult reopened_access = uplink_parse_access(access_string);
+        require_noerror(reopened_access.error);
+
+        UplinkProjectResult reopened_project = uplink_open_project(reopened_access.access);
+        require_noerror(reopened_project.error);
+
+        UplinkObjectResult object_result = uplink_stat_object(reopened_project.project, "alpha", "existing.txt");
+        require_noerror(object_result.error);
+        uplink_free_object_result(object_result);
+
+        object_result = uplink_stat_object(reopened_project.project, "alpha", "pending.txt");
+        require_error(object_result.error, UPLINK_ERROR_OBJECT_NOT_FOUND);
+        uplink_free_object_result(object_result);
+
+        uplink_free_project_result(reopened_project);
+        uplink_free_access_result(reopened_access);
+    }
+
+    requiref(uplink_internal_UniverseIsEmpty(), "universe is not empty\n");
+
+    return 0;
+}
+
+void upload_object(UplinkProject *project, const char *bucket, const char *key, size_t data_len)
+{
+    uint8_t *data = malloc(data_len);
+    fill_random_data(data, data_len);
+
+    UplinkUploadResult upload_result = uplink_upload_object(project, bucket, key, NULL);
+    require_noerror(upload_result.error);
+
+    size_t uploaded_total = 0;
+    while (uploaded_total < data_len) {
+        UplinkWriteResult result =
+            uplink_upload_write(upload_result.upload, data + uploaded_total, data_len - uploaded_total);
+        require_noerror(result.error);
+        uploaded_total += result.bytes_written;
+        uplink_free_write_result(result);
+    }
+
+    UplinkError *commit_err = uplink_upload_commit(upload_result.upload);
+    require_noerror(commit_err);
+
+    uplink_free_upload_result(upload_result);
+    free(data);
+}
@@ -0,0 +1,150 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+#include <pthread.h>
+#include <string.h>
+
+#include "../require.h"
+#include "helpers.h"
+#include "uplink.h"
+
+#define TRACE_ID 4242
+#define PARENT_SPAN_ID 1717
+
+typedef struct {
+    pthread_mutex_t lock;
+    int started;
+    int finished;
+    int upload_object;
+    int upload_write_bytes;
+    int stat_object_error;
+    int remote_parent;
+    int remote_write;
+    int internal;
+} trace_state;
+
+static void span_callback(int32_t event, const UplinkSpan *span, void *user_data)
+{
+    trace_state *state = (trace_state *)user_data;
+
+    pthread_mutex_lock(&state->lock);
+    if (event == UPLINK_SPAN_START) {
+        state->started++;
+    } else {
+        state->finished++;
+
+        if (strstr(span->name, "uplink_upload_object") != NULL) {
+            require(span->bucket != NULL && strcmp(span->bucket, "alpha") == 0);
+            require(span->key != NULL && strcmp(span->key, "traced.txt") == 0);
+            state->upload_object++;
+        }
+        if (strstr(span->name, "uplink_upload_write") != NULL) {
+            state->upload_write_bytes += (int)span->bytes;
+        }
+        if (strstr(span->name, "uplink_stat_object") != NULL && span->error_code == UPLINK_ERROR_OBJECT_NOT_FOUND) {
+            state->stat_object_error++;
+        }
+        if (span->trace_id == TRACE_ID && span->parent_id == PARENT_SPAN_ID) {
+            state->remote_parent++;
+        }
+        if (span->trace_id == TRACE_ID && strstr(span->name, "uplink_upload_write") != NULL) {
+            state->remote_write++;
+        }
+        if (strstr(span->name, "storj.io/uplink-c.") == NULL) {
+            state->internal++;
+        }
+    }
+    pthread_mutex_unlock(&state->lock);
+}
+
+trace_state state = {.lock = PTHREAD_MUTEX_INITIALIZER};
+
+void handle_project(UplinkProject *project);
+
+int main(void)
+{
+    UplinkError *err = uplink_set_span_callback(span_callback, &state);
+    require_noerror(err);
+
+    with_test_project(&handle_project);
+
+    require(state.started > 0);
+    require(state.upload_object == 2);
+    require(state.upload_write_bytes == 10);
+    require(state.stat_object_error == 1);
+    require(state.remote_parent == 2);
+    require(state.remote_write == 1);
+    require(state.internal > 0);
+
+    {
+        // disabling the callback stops all events
+        err = uplink_set_span_callback(NULL, NULL);
+        require_noerror(err);
+
+        int started = state.started;
+        UplinkAccessResult access_result = uplink_request_access_with_passphrase("127.0.0.1:1", "invalid", "");
+        require(access_result.error != NULL);
+        uplink_free_access_result(access_result);
+        require(state.started == started);
+    }
+
+    {
+        err = uplink_project_set_trace_parent(NULL, TRACE_ID, PARENT_SPAN_ID);
+        require_error(err, UPLINK_ERROR_INTERNAL);
+        uplink_free_error(err);
+    }
+
+    return 0;
+}
+
+void handle_project(UplinkProject *project)
+{
+    UplinkBucketResult bucket_result = uplink_ensure_bucket(project, "alpha");
+    require_noerror(bucket_result.error);
+    uplink_free_bucket_result(bucket_result);
+
+    {
+        UplinkUploadResult upload_result = uplink_upload_object(project, "alpha", "traced.txt", NULL);
+        require_noerror(upload_result.error);
+
+        UplinkWriteResult write_result = uplink_upload_write(upload_result.upload, "hello", 5);
+        require_noerror(write_result.error);
+        uplink_free_write_result(write_result);
+
+        UplinkError *err = uplink_upload_commit(upload_result.upload);
+        require_noerror(err);
+        uplink_free_upload_result(upload_result);
+    }
+
+    {
+        UplinkError *err = uplink_project_set_trace_parent(project, TRACE_ID, PARENT_SPAN_ID);
+        require_noerror(err);
+
+        UplinkObjectResult object_result = uplink_stat_object(project, "alpha", "missing.txt");
+        require_error(object_result.error, UPLINK_ERROR_OBJECT_NOT_FOUND);
+        uplink_free_object_result(object_result);
+
+        err = uplink_project_set_trace_parent(project, 0, 0);
+        require_noerror(err);
+    }
+
+    {
+        // the upload keeps the parent it was started with
+        UplinkError *err = uplink_project_set_trace_parent(project, TRACE_ID, PARENT_SPAN_ID);
+        require_noerror(err);
+
+        UplinkUploadResult upload_result = uplink_upload_object(project, "alpha", "traced.txt", NULL);
+        require_noerror(upload_result.error);
+
+        err = uplink_project_set_trace_parent(project, 0, 0);
+        require_noerror(err);
+
+        UplinkWriteResult write_result = uplink_upload_write(upload_result.upload, "hello", 5);
+        require_noerror(write_result.error);
+        uplink_free_write_result(write_result);
+
+        err = uplink_upload_abort(upload_result.upload);
+        require_noerror(err);
+        uplink_free_upload_result(upload_result);
+    }
+}
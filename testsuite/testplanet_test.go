@@ -8,6 +8,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 
@@ -113,6 +114,9 @@ func CompileSharedAt(ctx *testcontext.Context, t *testing.T, workDir, name strin
 	base := ctx.File("build", name)
 
 	args := []string{"build", "-buildmode", "c-shared"}
+	// The uplink_testing build tag includes the test-only exports, such as
+	// the in-process auth service.
+	tags := []string{"uplink_testing"}
 	// When GOCOVERDIR is set, instrument the shared library so that the C
 	// host process produces binary coverage data on exit. The uplink_coverage
 	// build tag pulls in an init() that registers an atexit flush handler.
@@ -121,9 +125,10 @@ func CompileSharedAt(ctx *testcontext.Context, t *testing.T, workDir, name strin
 			"-cover",
 			"-covermode=atomic",
 			"-coverpkg=./...",
-			"-tags=uplink_coverage",
 		)
+		tags = append(tags, "uplink_coverage")
 	}
+	args = append(args, "-tags="+strings.Join(tags, ","))
 	args = append(args, "-o", base+".so", pkg)
 
 	// not using race detector for c-shared
@@ -0,0 +1,215 @@
+// Copyright (C) 2026 Storj Labs, Inc.
+// See LICENSE for copying information.
+
+package main
+
+// #include "uplink_definitions.h"
+import "C"
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+
+	"github.com/spacemonkeygo/monkit/v3"
+)
+
+// mon is the scope of the spans started for exported calls.
+var mon = monkit.ScopeNamed("storj.io/uplink-c")
+
+// tracer is the span callback registered by the caller.
+type tracer struct {
+	callback C.UplinkSpanCallback
+	userData unsafe.Pointer
+}
+
+var (
+	// currentTracer is nil when tracing is disabled.
+	currentTracer atomic.Pointer[tracer]
+	// observeTraces registers the span observer on first use.
+	observeTraces sync.Once
+)
+
+// uplink_set_span_callback sets the callback that receives span start and
+// finish events. Passing NULL as callback disables tracing.
+//
+// Spans are reported for exported calls and for the internal phases of the
+// calls, such as dialing, uploading segments and committing objects.
+//
+// The callback may be called concurrently from multiple threads and must not
+// call back into the library.
+//
+//export uplink_set_span_callback
+func uplink_set_span_callback(callback C.UplinkSpanCallback, user_data unsafe.Pointer) *C.UplinkError { //nolint:golint
+	if callback == nil {
+		currentTracer.Store(nil)
+		return nil
+	}
+
+	observeTraces.Do(func() {
+		monkit.Default.ObserveTraces(func(trace *monkit.Trace) {
+			trace.ObserveSpans(spanObserver{})
+		})
+	})
+
+	currentTracer.Store(&tracer{
+		callback: callback,
+		userData: user_data,
+	})
+	return nil
+}
+
+// traceParent is the span of the caller that the spans of the operations
+// started on a project continue.
+type traceParent struct {
+	traceID int64
+	spanID  int64
+}
+
+// uplink_project_set_trace_parent makes the spans of the following calls on
+// the project children of the caller's span parent_span_id in trace trace_id.
+// Passing 0 as trace_id clears the parent.
+//
+// The parent is set for the project, not for the calling thread, so it
+// applies to calls made from any thread. Uploads, downloads and iterators
+// keep the parent they were started with, also when the parent of the
+// project is changed afterwards. Concurrent operations with different
+// parents need a project per parent.
+//
+//export uplink_project_set_trace_parent
+func uplink_project_set_trace_parent(project *C.UplinkProject, trace_id, parent_span_id C.int64_t) *C.UplinkError { //nolint:golint
+	if project == nil {
+		return mallocError(ErrNull.New("project"))
+	}
+
+	proj, ok := universe.Get(project._handle).(*Project)
+	if !ok {
+		return mallocError(ErrInvalidHandle.New("project"))
+	}
+
+	if trace_id == 0 {
+		proj.traceParent.Store(nil)
+		return nil
+	}
+
+	proj.traceParent.Store(&traceParent{
+		traceID: int64(trace_id),
+		spanID:  int64(parent_span_id),
+	})
+	return nil
+}
+
+// spanAttributesKey is the context key for spanAttributes.
+type spanAttributesKey struct{}
+
+// spanAttributes are the attributes of the span of an exported call. Spans
+// of internal phases inherit the bucket and the key.
+type spanAttributes struct {
+	bucket string
+	key    string
+	bytes  atomic.Int64
+
+	// span is the span of the exported call.
+	span atomic.Pointer[monkit.Span]
+}
+
+// callSpan is the span of an exported call.
+type callSpan struct {
+	attrs *spanAttributes
+	exit  func(*error)
+}
+
+// startSpan starts a span for the exported call name and replaces ctx with
+// the context of the span. When parent is nil, the span is a child of the
+// span in ctx. Empty bucket and key are inherited from the span in ctx.
+func startSpan(ctx *context.Context, parent *traceParent, name, bucket, key string) *callSpan {
+	attrs := &spanAttributes{bucket: bucket, key: key}
+	if inherited, ok := (*ctx).Value(spanAttributesKey{}).(*spanAttributes); ok && bucket == "" && key == "" {
+		attrs.bucket, attrs.key = inherited.bucket, inherited.key
+	}
+	*ctx = context.WithValue(*ctx, spanAttributesKey{}, attrs)
+
+	var exit func(*error)
+	if parent != nil {
+		exit = mon.FuncNamed(name).RemoteTrace(ctx, parent.spanID, monkit.NewTrace(parent.traceID))
+	} else {
+		exit = mon.FuncNamed(name).Task(ctx)
+	}
+	attrs.span.Store(monkit.SpanFromCtx(*ctx))
+
+	return &callSpan{attrs: attrs, exit: exit}
+}
+
+// startSpan starts a span for an exported call on the project.
+func (proj *Project) startSpan(ctx *context.Context, name, bucket, key string) *callSpan {
+	return startSpan(ctx, proj.traceParent.Load(), name, bucket, key)
+}
+
+// addBytes adds n to the bytes transferred by the call.
+func (span *callSpan) addBytes(n int) {
+	span.attrs.bytes.Add(int64(n))
+}
+
+// finish finishes the span with err.
+func (span *callSpan) finish(err error) {
+	span.exit(&err)
+}
+
+// spanObserver reports spans to the current tracer.
+type spanObserver struct{}
+
+// Start implements monkit.SpanObserver.
+func (spanObserver) Start(s *monkit.Span) {
+	reportSpan(C.UPLINK_SPAN_START, s, nil, 0)
+}
+
+// Finish implements monkit.SpanObserver.
+func (spanObserver) Finish(s *monkit.Span, err error, panicked bool, finish time.Time) {
+	reportSpan(C.UPLINK_SPAN_FINISH, s, err, finish.Sub(s.Start()))
+}
+
+// reportSpan calls the span callback, when tracing is enabled.
+func reportSpan(event C.int32_t, s *monkit.Span, err error, duration time.Duration) {
+	current := currentTracer.Load()
+	if current == nil {
+		return
+	}
+
+	span := (*C.UplinkSpan)(calloc(1, C.sizeof_UplinkSpan))
+	defer free(unsafe.Pointer(span))
+
+	span.trace_id = C.int64_t(s.Trace().Id())
+	span.span_id = C.int64_t(s.Id())
+	if parentID, ok := s.ParentId(); ok {
+		span.parent_id = C.int64_t(parentID)
+	}
+
+	span.name = cstring(s.Func().FullName())
+	defer free(unsafe.Pointer(span.name))
+
+	if attrs, ok := s.Value(spanAttributesKey{}).(*spanAttributes); ok {
+		if attrs.bucket != "" {
+			span.bucket = cstring(attrs.bucket)
+			defer free(unsafe.Pointer(span.bucket))
+		}
+		if attrs.key != "" {
+			span.key = cstring(attrs.key)
+			defer free(unsafe.Pointer(span.key))
+		}
+		if attrs.span.Load() == s {
+			span.bytes = C.int64_t(attrs.bytes.Load())
+		}
+	}
+
+	if event == C.UPLINK_SPAN_FINISH {
+		span.duration_ns = C.int64_t(duration.Nanoseconds())
+		if err != nil && !errors.Is(err, io.EOF) {
+			span.error_code = errorCode(err)
+		}
+	}
+
+	callSpanCallback(current.callback, event, span, current.userData)
+}
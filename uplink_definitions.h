@@ -115,6 +115,63 @@ typedef struct UplinkListBucketsOptions {
     const char *cursor;
 } UplinkListBucketsOptions;
 
+typedef struct UplinkDeleteObjectsOptions {
+    // concurrency is the number of objects deleted in parallel.
+    // When concurrency is 0 or negative, the default of 10 is used.
+    // Values above 1000 are lowered to 1000.
+    int32_t concurrency;
+    // skip_metadata doesn't return the metadata of the deleted objects.
+    bool skip_metadata;
+} UplinkDeleteObjectsOptions;
+
+typedef struct UplinkDeletePrefixProgress {
+    // objects_listed and bytes_listed count the objects found under the prefix so far.
+    int64_t objects_listed;
+    int64_t bytes_listed;
+    // objects_deleted and bytes_deleted count the objects deleted so far.
+    int64_t objects_deleted;
+    int64_t bytes_deleted;
+    // objects_failed is the number of objects that failed to delete so far.
+    int64_t objects_failed;
+} UplinkDeletePrefixProgress;
+
+// UplinkDeletePrefixCallback is called for every object handled by uplink_delete_prefix.
+// error_code is 0 when the object was deleted, or only listed with dry_run.
+// progress and key are only valid for the duration of the call.
+// The callback is not called concurrently.
+typedef void (*UplinkDeletePrefixCallback)(const UplinkDeletePrefixProgress *progress, const char *key,
+                                           int32_t error_code, void *user_data);
+
+typedef struct UplinkDeletePrefixOptions {
+    // concurrency is the number of objects deleted in parallel.
+    // When concurrency is 0 or negative, the default of 10 is used.
+    // Values above 1000 are lowered to 1000.
+    int32_t concurrency;
+    // dry_run only lists and counts the objects without deleting them.
+    bool dry_run;
+    // progress is optional.
+    UplinkDeletePrefixCallback progress;
+    void *user_data;
+} UplinkDeletePrefixOptions;
+
+typedef struct UplinkCopyPrefixOptions {
+    // concurrency is the number of objects copied in parallel.
+    // When concurrency is 0 or negative, the default of 10 is used.
+    // Values above 1000 are lowered to 1000.
+    int32_t concurrency;
+    // cursor resumes after the key relative to the old prefix, as returned in UplinkPrefixReport.
+    const char *cursor;
+} UplinkCopyPrefixOptions;
+
+typedef struct UplinkMovePrefixOptions {
+    // concurrency is the number of objects moved in parallel.
+    // When concurrency is 0 or negative, the default of 10 is used.
+    // Values above 1000 are lowered to 1000.
+    int32_t concurrency;
+    // cursor resumes after the key relative to the old prefix, as returned in UplinkPrefixReport.
+    const char *cursor;
+} UplinkMovePrefixOptions;
+
 typedef struct UplinkObjectIterator {
     size_t _handle;
 } UplinkObjectIterator;
@@ -143,6 +200,18 @@ typedef struct UplinkPermission {
     // unix time in seconds when the permission becomes invalid.
     // disabled when 0.
     int64_t not_after;
+
+    // maximum time-to-live of uploaded objects in seconds.
+    // objects without an expiration are uploaded with this time-to-live.
+    // requires allow_upload, disabled when 0.
+    int64_t max_object_ttl;
+
+    bool allow_put_object_retention;
+    bool allow_get_object_retention;
+    bool allow_put_object_legal_hold;
+    bool allow_get_object_legal_hold;
+    // requires allow_delete or allow_put_object_retention.
+    bool allow_bypass_governance_retention;
 } UplinkPermission;
 
 typedef struct UplinkPart {
@@ -159,11 +228,40 @@ typedef struct UplinkSharePrefix {
     const char *prefix;
 } UplinkSharePrefix;
 
+typedef struct UplinkKeyringEntry {
+    char *name;
+    char *description;
+    char *satellite_address;
+    // unix time in seconds when the entry was first and last saved.
+    int64_t created;
+    int64_t updated;
+} UplinkKeyringEntry;
+
+typedef struct UplinkEncryptionKeyOverride {
+    char *bucket;
+    // prefix ends with "/", it is empty when the override is for the whole bucket.
+    char *prefix;
+} UplinkEncryptionKeyOverride;
+
+typedef struct UplinkSharePrefixPermission {
+    UplinkSharePrefix prefix;
+    UplinkPermission permission;
+} UplinkSharePrefixPermission;
+
 typedef struct UplinkError {
     int32_t code;
     char *message;
 } UplinkError;
 
+// Memory functions used for everything the library returns to the caller.
+typedef struct UplinkAllocator {
+    // malloc_fn and free_fn are mandatory.
+    void *(*malloc_fn)(size_t size);
+    // calloc_fn is optional, when NULL memory is allocated with malloc_fn and zeroed.
+    void *(*calloc_fn)(size_t nitems, size_t size);
+    void (*free_fn)(void *ptr);
+} UplinkAllocator;
+
 #define UPLINK_ERROR_INTERNAL 0x02
 #define UPLINK_ERROR_CANCELED 0x03
 #define UPLINK_ERROR_INVALID_HANDLE 0x04
@@ -185,16 +283,129 @@ typedef struct UplinkError {
 #define EDGE_ERROR_AUTH_DIAL_FAILED 0x30
 #define EDGE_ERROR_REGISTER_ACCESS_FAILED 0x31
 
+// UPLINK_ERROR_CODE_LIMIT is larger than any error code.
+#define UPLINK_ERROR_CODE_LIMIT 0x40
+
+#define UPLINK_OPERATION_STAT_BUCKET 0x00
+#define UPLINK_OPERATION_CREATE_BUCKET 0x01
+#define UPLINK_OPERATION_ENSURE_BUCKET 0x02
+#define UPLINK_OPERATION_DELETE_BUCKET 0x03
+#define UPLINK_OPERATION_DELETE_BUCKET_WITH_OBJECTS 0x04
+#define UPLINK_OPERATION_LIST_BUCKETS 0x05
+#define UPLINK_OPERATION_STAT_OBJECT 0x06
+#define UPLINK_OPERATION_UPLOAD_OBJECT 0x07
+#define UPLINK_OPERATION_DOWNLOAD_OBJECT 0x08
+#define UPLINK_OPERATION_DELETE_OBJECT 0x09
+#define UPLINK_OPERATION_LIST_OBJECTS 0x0a
+#define UPLINK_OPERATION_UPDATE_OBJECT_METADATA 0x0b
+#define UPLINK_OPERATION_COPY_OBJECT 0x0c
+#define UPLINK_OPERATION_MOVE_OBJECT 0x0d
+#define UPLINK_OPERATION_BEGIN_UPLOAD 0x0e
+#define UPLINK_OPERATION_COMMIT_UPLOAD 0x0f
+#define UPLINK_OPERATION_ABORT_UPLOAD 0x10
+#define UPLINK_OPERATION_UPLOAD_PART 0x11
+#define UPLINK_OPERATION_LIST_UPLOADS 0x12
+#define UPLINK_OPERATION_LIST_UPLOAD_PARTS 0x13
+#define UPLINK_OPERATION_REVOKE_ACCESS 0x14
+// UPLINK_OPERATION_COUNT is the number of UPLINK_OPERATION_* values.
+#define UPLINK_OPERATION_COUNT 0x15
+
+#define UPLINK_SPAN_START 0x00
+#define UPLINK_SPAN_FINISH 0x01
+
+#define UPLINK_LOG_LEVEL_DEBUG 0x00
+#define UPLINK_LOG_LEVEL_INFO 0x01
+#define UPLINK_LOG_LEVEL_WARN 0x02
+#define UPLINK_LOG_LEVEL_ERROR 0x03
+
+typedef struct UplinkLogField {
+    const char *key;
+    const char *value;
+} UplinkLogField;
+
+// UplinkLogCallback receives diagnostic events from the library.
+// message and fields are only valid for the duration of the call.
+// The callback may be called concurrently from multiple threads.
+typedef void (*UplinkLogCallback)(int32_t level, const char *message, const UplinkLogField *fields,
+                                  size_t fields_count, void *user_data);
+
+// UplinkSpan describes an exported call or an internal phase of a call.
+typedef struct UplinkSpan {
+    int64_t trace_id;
+    int64_t span_id;
+    // parent_id is 0 when the span has no parent.
+    int64_t parent_id;
+    const char *name;
+
+    // bucket and key are NULL when the call is not about a bucket or an object.
+    const char *bucket;
+    const char *key;
+    // bytes is the number of bytes transferred by an exported call.
+    int64_t bytes;
+
+    // duration_ns and error_code are only set when the span finishes.
+    int64_t duration_ns;
+    int32_t error_code;
+} UplinkSpan;
+
+// UplinkSpanCallback receives UPLINK_SPAN_START and UPLINK_SPAN_FINISH events.
+// span is only valid for the duration of the call.
+// The callback may be called concurrently from multiple threads.
+typedef void (*UplinkSpanCallback)(int32_t event, const UplinkSpan *span, void *user_data);
+
+// UplinkMetricCallback receives a single metric from uplink_metrics_iterate.
+// series and field are only valid for the duration of the call.
+typedef void (*UplinkMetricCallback)(const char *series, const char *field, double value, void *user_data);
+
+// UplinkProjectStats contains cumulative statistics of a project since it was opened.
+typedef struct UplinkProjectStats {
+    int64_t bytes_uploaded;
+    int64_t bytes_downloaded;
+
+    // uploads and downloads that have been started, but not yet finished.
+    int64_t uploads_in_progress;
+    int64_t downloads_in_progress;
+
+    // operations is indexed by UPLINK_OPERATION_*, operations that are
+    // not less than operations_count are not known to the library.
+    int64_t *operations;
+    size_t operations_count;
+    // errors is indexed by UPLINK_ERROR_*, codes that are not less than
+    // errors_count are not known to the library.
+    int64_t *errors;
+    size_t errors_count;
+} UplinkProjectStats;
+
 typedef struct UplinkAccessResult {
     UplinkAccess *access;
     UplinkError *error;
 } UplinkAccessResult;
 
+typedef struct UplinkSharePrefixesResult {
+    // accesses has an access grant for every distinct permission of the
+    // entries, in the order in which the permissions first appear.
+    UplinkAccess **accesses;
+    size_t accesses_count;
+    UplinkError *error;
+} UplinkSharePrefixesResult;
+
+typedef struct UplinkCLIConfigResult {
+    UplinkAccess *access;
+    // config is the base config with the settings of the uplink CLI applied.
+    UplinkConfig config;
+    UplinkError *error;
+} UplinkCLIConfigResult;
+
 typedef struct UplinkProjectResult {
     UplinkProject *project;
     UplinkError *error;
 } UplinkProjectResult;
 
+typedef struct UplinkProjectStatsResult {
+    UplinkProjectStats *stats;
+    UplinkError *error;
+} UplinkProjectStatsResult;
+
 typedef struct UplinkBucketResult {
     UplinkBucket *bucket;
     UplinkError *error;
@@ -235,11 +446,99 @@ typedef struct UplinkStringResult {
     UplinkError *error;
 } UplinkStringResult;
 
+typedef struct UplinkBoolResult {
+    bool value;
+    UplinkError *error;
+} UplinkBoolResult;
+
+typedef struct UplinkTimeResult {
+    // unix time in seconds, 0 when not set.
+    int64_t time;
+    UplinkError *error;
+} UplinkTimeResult;
+
+typedef struct UplinkKeyringListResult {
+    UplinkKeyringEntry *entries;
+    size_t entries_count;
+    UplinkError *error;
+} UplinkKeyringListResult;
+
+typedef struct UplinkEncryptionKeyOverridesResult {
+    UplinkEncryptionKeyOverride *overrides;
+    size_t overrides_count;
+    UplinkError *error;
+} UplinkEncryptionKeyOverridesResult;
+
+typedef struct UplinkRevokeAccessesResult {
+    // errors has an element for every access grant, NULL when it was revoked.
+    UplinkError **errors;
+    size_t errors_count;
+    // failed_count is the number of access grants that were not revoked.
+    size_t failed_count;
+    UplinkError *error;
+} UplinkRevokeAccessesResult;
+
+typedef struct UplinkDeleteObjectsItem {
+    // code is 0 when the key was deleted or didn't exist, otherwise the code of error.
+    int32_t code;
+    // deleted is whether an object existed at the key.
+    bool deleted;
+    // object is NULL when nothing was deleted or skip_metadata was set.
+    UplinkObject *object;
+    UplinkError *error;
+} UplinkDeleteObjectsItem;
+
+typedef struct UplinkDeleteObjectsResult {
+    // items has an element for every key in the same order.
+    UplinkDeleteObjectsItem *items;
+    size_t items_count;
+    // failed_count is the number of keys that failed to delete.
+    size_t failed_count;
+    UplinkError *error;
+} UplinkDeleteObjectsResult;
+
+typedef struct UplinkDeletePrefixResult {
+    UplinkDeletePrefixProgress progress;
+    UplinkError *error;
+} UplinkDeletePrefixResult;
+
+typedef struct UplinkPrefixFailure {
+    char *key;
+    UplinkError *error;
+} UplinkPrefixFailure;
+
+// UplinkPrefixReport describes the outcome of uplink_copy_prefix and uplink_move_prefix.
+typedef struct UplinkPrefixReport {
+    int64_t objects_listed;
+    // objects_completed and bytes_completed count the objects copied or moved.
+    int64_t objects_completed;
+    int64_t bytes_completed;
+    int64_t objects_failed;
+    // cursor is the key relative to the old prefix up to which all objects were copied or moved.
+    // It can be passed as options cursor to resume, it's the options cursor when nothing was done.
+    char *cursor;
+    // failures has an element for every object that failed.
+    UplinkPrefixFailure *failures;
+    size_t failures_count;
+} UplinkPrefixReport;
+
+typedef struct UplinkPrefixReportResult {
+    // report is set when the operation was started, even when it failed.
+    UplinkPrefixReport *report;
+    UplinkError *error;
+} UplinkPrefixReportResult;
+
 typedef struct UplinkEncryptionKeyResult {
     UplinkEncryptionKey *encryption_key;
     UplinkError *error;
 } UplinkEncryptionKeyResult;
 
+typedef struct UplinkSaltResult {
+    uint8_t *salt;
+    size_t salt_length;
+    UplinkError *error;
+} UplinkSaltResult;
+
 typedef struct UplinkUploadInfo {
     char *upload_id;
 
@@ -292,6 +591,9 @@ typedef struct EdgeConfig {
 typedef struct EdgeRegisterAccessOptions {
     // Wether objects can be read using only the access_key_id.
     bool is_public;
+    // Unix time in seconds when the credentials expire, 0 for no expiry
+    // other than the expiry of the access.
+    int64_t expires;
 } EdgeRegisterAccessOptions;
 
 // Gateway credentials in S3 format
@@ -302,18 +604,73 @@ typedef struct EdgeCredentials {
     // Base HTTP(S) URL to the gateway.
     // The gateway and linkshare service are different endpoints.
     const char *endpoint;
+    // Unix time in seconds when the credentials expire, 0 when they don't.
+    // It can be earlier than requested because of free-tier limits.
+    int64_t expires;
+    // Whether objects can be read using only the access_key_id.
+    bool is_public;
 } EdgeCredentials;
 
 typedef struct EdgeCredentialsResult {
     EdgeCredentials *credentials;
+    // Serialized access grant registered for the credentials, revoking it
+    // with uplink_revoke_serialized_access revokes the credentials.
+    char *access;
     UplinkError *error;
 } EdgeCredentialsResult;
 
+// HTTP header included in a presigned URL signature.
+typedef struct EdgeHeader {
+    const char *name;
+    const char *value;
+} EdgeHeader;
+
 typedef struct EdgeShareURLOptions {
     // Serve the file directly rather than through a landing page.
     bool raw;
 } EdgeShareURLOptions;
 
+#define EDGE_SHARE_MANIFEST_JSON 0
+#define EDGE_SHARE_MANIFEST_CSV 1
+
+typedef struct EdgeShareManifestOptions {
+    // EDGE_SHARE_MANIFEST_JSON or EDGE_SHARE_MANIFEST_CSV.
+    int32_t format;
+    // Unix time in seconds when the registered access expires, 0 for no expiry
+    // other than the expiry of the access.
+    int64_t expires;
+} EdgeShareManifestOptions;
+
+typedef struct EdgeShareManifestResult {
+    // Credentials of the registered public access.
+    EdgeCredentials *credentials;
+    // Serialized access grant registered for the credentials, revoking it
+    // with uplink_revoke_serialized_access revokes the credentials.
+    char *access;
+    char *manifest;
+    UplinkError *error;
+} EdgeShareManifestResult;
+
+// Parts of a linkshare URL.
+typedef struct EdgeShareURL {
+    // Linkshare service, e.g. https://link.storjshare.io
+    char *base_url;
+    char *access_key_id;
+    // Empty when the entire project is shared.
+    char *bucket;
+    // Object key or prefix, empty when the entire bucket is shared.
+    char *key;
+    // Whether the URL serves the file directly rather than through a landing page.
+    bool raw;
+    // Whether the URL shares a prefix, a bucket or the entire project.
+    bool is_prefix;
+} EdgeShareURL;
+
+typedef struct EdgeShareURLResult {
+    EdgeShareURL *share_url;
+    UplinkError *error;
+} EdgeShareURLResult;
+
 // we need to suppress 'pedantic' validation because struct is empty for now
 #pragma GCC diagnostic push
 #pragma GCC diagnostic ignored "-Wpedantic"
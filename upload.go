@@ -6,6 +6,7 @@ package main
 // #include "uplink_definitions.h"
 import "C"
 import (
+	"errors"
 	"time"
 	"unsafe"
 
@@ -16,6 +17,9 @@ import (
 type Upload struct {
 	scope
 	upload *uplink.Upload
+
+	stats    *projectStats
+	progress inProgress
 }
 
 // uplink_upload_object starts an upload to the specified key.
@@ -53,15 +57,22 @@ func uplink_upload_object(project *C.UplinkProject, bucket_name, object_key *C.u
 		}
 	}
 
+	logDebug("starting upload", "bucket", C.GoString(bucket_name), "key", C.GoString(object_key))
+	span := proj.startSpan(&scope.ctx, "uplink_upload_object", C.GoString(bucket_name), C.GoString(object_key))
 	upload, err := proj.UploadObject(scope.ctx, C.GoString(bucket_name), C.GoString(object_key), opts)
+	span.finish(err)
+	proj.stats.record(C.UPLINK_OPERATION_UPLOAD_OBJECT, err)
 	if err != nil {
 		return C.UplinkUploadResult{
 			error: mallocError(err),
 		}
 	}
 
+	up := &Upload{scope: scope, upload: upload, stats: proj.stats}
+	up.progress.start(&proj.stats.uploads)
+
 	return C.UplinkUploadResult{
-		upload: (*C.UplinkUpload)(mallocHandle(universe.Add(&Upload{scope, upload}))),
+		upload: (*C.UplinkUpload)(mallocHandle(universe.Add(up))),
 	}
 }
 
@@ -98,7 +109,13 @@ func uplink_upload_write(upload *C.UplinkUpload, bytes unsafe.Pointer, length C.
 	}
 
 	buf := unsafe.Slice((*byte)(bytes), ilength)
+	ctx := up.scope.ctx
+	span := startSpan(&ctx, nil, "uplink_upload_write", "", "")
 	n, err := up.upload.Write(buf)
+	span.addBytes(n)
+	span.finish(err)
+	up.stats.bytesUploaded.Add(int64(n))
+	up.stats.recordError(err)
 	return C.UplinkWriteResult{
 		bytes_written: C.size_t(n),
 		error:         mallocError(err),
@@ -118,7 +135,15 @@ func uplink_upload_commit(upload *C.UplinkUpload) *C.UplinkError {
 		return mallocError(ErrInvalidHandle.New("upload"))
 	}
 
+	ctx := up.scope.ctx
+	span := startSpan(&ctx, nil, "uplink_upload_commit", "", "")
 	err := up.upload.Commit()
+	span.finish(err)
+	up.progress.finish()
+	up.stats.recordError(err)
+	if err == nil {
+		logDebug("upload committed", "key", up.upload.Info().Key, "content_length", up.upload.Info().System.ContentLength)
+	}
 	return mallocError(err)
 }
 
@@ -135,7 +160,13 @@ func uplink_upload_abort(upload *C.UplinkUpload) *C.UplinkError {
 		return mallocError(ErrInvalidHandle.New("upload"))
 	}
 
+	logDebug("aborting upload", "key", up.upload.Info().Key)
+	ctx := up.scope.ctx
+	span := startSpan(&ctx, nil, "uplink_upload_abort", "", "")
 	err := up.upload.Abort()
+	span.finish(err)
+	up.progress.finish()
+	up.stats.recordError(err)
 	return mallocError(err)
 }
 
@@ -176,7 +207,11 @@ func uplink_upload_set_custom_metadata(upload *C.UplinkUpload, custom C.UplinkCu
 	}
 
 	customMetadata := customMetadataFromC(custom)
-	err := up.upload.SetCustomMetadata(up.scope.ctx, customMetadata)
+	ctx := up.scope.ctx
+	span := startSpan(&ctx, nil, "uplink_upload_set_custom_metadata", "", "")
+	err := up.upload.SetCustomMetadata(ctx, customMetadata)
+	span.finish(err)
+	up.stats.recordError(err)
 
 	return mallocError(err)
 }
@@ -200,11 +235,18 @@ func freeUpload(upload *C.UplinkUpload) {
 	if upload == nil {
 		return
 	}
-	defer C.free(unsafe.Pointer(upload))
+	defer free(unsafe.Pointer(upload))
 	defer universe.Del(upload._handle)
 
 	up, ok := universe.Get(upload._handle).(*Upload)
-	if ok {
-		up.cancel()
+	if !ok {
+		return
+	}
+
+	up.progress.finish()
+	// in case we haven't already committed or aborted the upload
+	if err := up.upload.Abort(); err != nil && !errors.Is(err, uplink.ErrUploadDone) {
+		logError("aborting upload on free failed", "error", err)
 	}
+	up.cancel()
 }
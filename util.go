@@ -5,7 +5,11 @@ package main
 
 // #include "uplink_definitions.h"
 import "C"
-import "time"
+import (
+	"context"
+	"sync"
+	"time"
+)
 
 // safeConvertToInt converts the C.size_t to an int, and returns a boolean
 // indicating if the conversion was lossless and semantically equivalent.
@@ -20,3 +24,34 @@ func timeToUnix(t time.Time) C.int64_t {
 	}
 	return C.int64_t(t.Unix())
 }
+
+// parallel calls fn for every index below count with at most concurrency
+// calls running at the same time and returns the error of each call. Calls
+// that haven't started when ctx is canceled fail with the context error.
+func parallel(ctx context.Context, count, concurrency int, fn func(ctx context.Context, i int) error) []error {
+	errs := make([]error, count)
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var wg sync.WaitGroup
+	limiter := make(chan struct{}, concurrency)
+	for i := 0; i < count; i++ {
+		limiter <- struct{}{}
+		if err := ctx.Err(); err != nil {
+			<-limiter
+			errs[i] = err
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-limiter }()
+			errs[i] = fn(ctx, i)
+		}(i)
+	}
+	wg.Wait()
+
+	return errs
+}